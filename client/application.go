@@ -42,6 +42,25 @@ func (c *Client) ListHostApplications(ctx context.Context) ([]model.HostApplicat
 	return apps, nil
 }
 
+// ListHostApplicationsWithOptions returns the page of applications selected by the options and the total number of
+// applications.
+func (c *Client) ListHostApplicationsWithOptions(ctx context.Context, options model.ListOptions) ([]model.HostApplication, int, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostAppsPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genListQuery(options), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	var apps []model.HostApplication
+	total, err := c.execListRequest(req, &apps)
+	if err != nil {
+		return nil, 0, err
+	}
+	return apps, total, nil
+}
+
 func (c *Client) AddHostApplication(ctx context.Context, appResBase model.HostApplicationBase) (string, error) {
 	u, err := url.JoinPath(c.baseUrl, model.HostAppsPath)
 	if err != nil {
@@ -58,6 +77,14 @@ func (c *Client) AddHostApplication(ctx context.Context, appResBase model.HostAp
 	return c.baseClient.ExecRequestString(req)
 }
 
+func (c *Client) UpdateHostApplication(ctx context.Context, aID string, appResBase model.HostApplicationBase) error {
+	return c.execHostAppRequest(ctx, http.MethodPut, aID, appResBase)
+}
+
+func (c *Client) PatchHostApplication(ctx context.Context, aID string, patch model.HostApplicationPatch) error {
+	return c.execHostAppRequest(ctx, http.MethodPatch, aID, patch)
+}
+
 func (c *Client) RemoveHostApplication(ctx context.Context, aID string) error {
 	u, err := url.JoinPath(c.baseUrl, model.HostAppsPath, aID)
 	if err != nil {
@@ -69,3 +96,36 @@ func (c *Client) RemoveHostApplication(ctx context.Context, aID string) error {
 	}
 	return c.baseClient.ExecRequestVoid(req)
 }
+
+func (c *Client) GetHostApplicationHealth(ctx context.Context, aID string) (model.HostAppHealth, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostAppsPath, aID, model.HealthPath)
+	if err != nil {
+		return model.HostAppHealth{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.HostAppHealth{}, err
+	}
+	var health model.HostAppHealth
+	err = c.baseClient.ExecRequestJSON(req, &health)
+	if err != nil {
+		return model.HostAppHealth{}, err
+	}
+	return health, nil
+}
+
+func (c *Client) execHostAppRequest(ctx context.Context, method, aID string, v any) error {
+	u, err := url.JoinPath(c.baseUrl, model.HostAppsPath, aID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
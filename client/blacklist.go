@@ -43,6 +43,11 @@ func (c *Client) GetNetItfBlacklist(ctx context.Context) ([]string, error) {
 	return values, nil
 }
 
+// GetNetItfBlacklistWithOptions returns the page of values selected by the options and the total number of values.
+func (c *Client) GetNetItfBlacklistWithOptions(ctx context.Context, options model.ListOptions) ([]string, int, error) {
+	return c.getStringList(ctx, options, model.BlacklistsPath, model.NetInterfacesPath)
+}
+
 func (c *Client) NetItfBlacklistAdd(ctx context.Context, v string) error {
 	u, err := url.JoinPath(c.baseUrl, model.BlacklistsPath, model.NetInterfacesPath)
 	if err != nil {
@@ -88,6 +93,11 @@ func (c *Client) GetNetRngBlacklist(ctx context.Context) ([]string, error) {
 	return values, nil
 }
 
+// GetNetRngBlacklistWithOptions returns the page of values selected by the options and the total number of values.
+func (c *Client) GetNetRngBlacklistWithOptions(ctx context.Context, options model.ListOptions) ([]string, int, error) {
+	return c.getStringList(ctx, options, model.BlacklistsPath, model.NetRangesPath)
+}
+
 func (c *Client) NetRngBlacklistAdd(ctx context.Context, v string) error {
 	u, err := url.JoinPath(c.baseUrl, model.BlacklistsPath, model.NetRangesPath)
 	if err != nil {
@@ -115,3 +125,49 @@ func (c *Client) NetRngBlacklistRemove(ctx context.Context, v string) error {
 	}
 	return c.baseClient.ExecRequestVoid(req)
 }
+
+func (c *Client) GetAppSocketBlacklist(ctx context.Context) ([]string, error) {
+	u, err := url.JoinPath(c.baseUrl, model.BlacklistsPath, model.AppSocketsPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	err = c.baseClient.ExecRequestJSON(req, &values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// GetAppSocketBlacklistWithOptions returns the page of values selected by the options and the total number of values.
+func (c *Client) GetAppSocketBlacklistWithOptions(ctx context.Context, options model.ListOptions) ([]string, int, error) {
+	return c.getStringList(ctx, options, model.BlacklistsPath, model.AppSocketsPath)
+}
+
+func (c *Client) AppSocketBlacklistAdd(ctx context.Context, v string) error {
+	u, err := url.JoinPath(c.baseUrl, model.BlacklistsPath, model.AppSocketsPath)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewBufferString(v))
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
+
+func (c *Client) AppSocketBlacklistRemove(ctx context.Context, v string) error {
+	u, err := url.JoinPath(c.baseUrl, model.BlacklistsPath, model.AppSocketsPath)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u+"?"+url.Values{"value": {v}}.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
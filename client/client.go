@@ -24,12 +24,14 @@ import (
 
 type Client struct {
 	baseClient *base_client.Client
+	httpClient base_client.HTTPClient
 	baseUrl    string
 }
 
 func New(httpClient base_client.HTTPClient, baseUrl string) *Client {
 	return &Client{
 		baseClient: base_client.New(httpClient, customError, model.HeaderRequestID),
+		httpClient: httpClient,
 		baseUrl:    baseUrl,
 	}
 }
@@ -42,6 +44,8 @@ func customError(code int, err error) error {
 		err = model.NewNotFoundError(err)
 	case http.StatusBadRequest:
 		err = model.NewInvalidInputError(err)
+	case http.StatusConflict:
+		err = model.NewConflictError(err)
 	}
 	return err
 }
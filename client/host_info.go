@@ -21,6 +21,7 @@ import (
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 func (c *Client) GetHostInfo(ctx context.Context) (model.HostInfo, error) {
@@ -56,3 +57,31 @@ func (c *Client) GetHostNet(ctx context.Context) (model.HostNet, error) {
 	}
 	return hostNet, nil
 }
+
+func (c *Client) GetHostNetNeighbors(ctx context.Context, filter model.NetNeighborFilter) ([]model.NetNeighbor, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostInfoPath, model.HostNetPath, model.HostNeighborsPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genNeighborsQuery(filter), nil)
+	if err != nil {
+		return nil, err
+	}
+	var neighbors []model.NetNeighbor
+	err = c.baseClient.ExecRequestJSON(req, &neighbors)
+	if err != nil {
+		return nil, err
+	}
+	return neighbors, nil
+}
+
+func genNeighborsQuery(filter model.NetNeighborFilter) string {
+	var items []string
+	for _, prefix := range filter.MACPrefixes {
+		items = append(items, "mac_prefix="+url.QueryEscape(prefix))
+	}
+	if len(items) > 0 {
+		return "?" + strings.Join(items, "&")
+	}
+	return ""
+}
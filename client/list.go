@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/go-base-http-client"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// headerRecorder keeps the header of the last response, so the total count of list responses can be read while
+// errors are still handled by the base client.
+type headerRecorder struct {
+	httpClient base_client.HTTPClient
+	header     http.Header
+}
+
+func (r *headerRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	r.header = resp.Header
+	return resp, nil
+}
+
+// execListRequest decodes the response into v and returns the total number of items provided by the server.
+func (c *Client) execListRequest(req *http.Request, v any) (int, error) {
+	rec := &headerRecorder{httpClient: c.httpClient}
+	if err := base_client.New(rec, customError, model.HeaderRequestID).ExecRequestJSON(req, v); err != nil {
+		return 0, err
+	}
+	val := rec.header.Get(model.HeaderTotalCount)
+	if val == "" {
+		return 0, errors.New("missing total count")
+	}
+	total, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid total count '%s'", val)
+	}
+	return total, nil
+}
+
+func (c *Client) getStringList(ctx context.Context, options model.ListOptions, elem ...string) ([]string, int, error) {
+	u, err := url.JoinPath(c.baseUrl, elem...)
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genListQuery(options), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	var values []string
+	total, err := c.execListRequest(req, &values)
+	if err != nil {
+		return nil, 0, err
+	}
+	return values, total, nil
+}
+
+func setListQuery(q url.Values, options model.ListOptions) {
+	if options.Sort != "" {
+		q.Set("sort", options.Sort)
+	}
+	if options.Order != "" {
+		q.Set("order", options.Order)
+	}
+	if options.Limit > 0 {
+		q.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Offset > 0 {
+		q.Set("offset", strconv.Itoa(options.Offset))
+	}
+	for _, field := range options.Fields {
+		q.Add("fields", field)
+	}
+}
+
+func genListQuery(options model.ListOptions) string {
+	q := url.Values{}
+	setListQuery(q, options)
+	if len(q) > 0 {
+		return "?" + q.Encode()
+	}
+	return ""
+}
@@ -18,8 +18,12 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/SENERGY-Platform/go-base-http-client"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -27,11 +31,17 @@ import (
 )
 
 func (c *Client) MDNSQueryService(ctx context.Context, service, domain string, timeWindow time.Duration) ([]model.MDNSEntry, error) {
+	return c.MDNSQueryServiceWithOptions(ctx, service, domain, timeWindow, model.MDNSQueryOptions{})
+}
+
+// MDNSQueryServiceWithOptions works like MDNSQueryService but allows bypassing the cache and limiting the query to
+// interfaces, TXT records or an IP type.
+func (c *Client) MDNSQueryServiceWithOptions(ctx context.Context, service, domain string, timeWindow time.Duration, options model.MDNSQueryOptions) ([]model.MDNSEntry, error) {
 	u, err := url.JoinPath(c.baseUrl, model.MDNSDiscoveryPath)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genMDNSQuery(service, domain, timeWindow), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genMDNSQuery(service, domain, timeWindow, options), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +53,88 @@ func (c *Client) MDNSQueryService(ctx context.Context, service, domain string, t
 	return entries, nil
 }
 
-func genMDNSQuery(srv, dom string, tw time.Duration) string {
+// MDNSQueryServiceStream returns a channel that receives entries as soon as they are discovered. The channel is closed
+// after the time window ended or ctx is done. The error channel receives an error if reading the stream failed and is
+// closed together with the entries channel.
+func (c *Client) MDNSQueryServiceStream(ctx context.Context, service, domain string, timeWindow time.Duration, options model.MDNSQueryOptions) (<-chan model.MDNSEntry, <-chan error, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSDiscoveryPath, model.StreamPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genMDNSQuery(service, domain, timeWindow, options), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	rec := &bodyRecorder{httpClient: c.httpClient}
+	if err = base_client.New(rec, customError, model.HeaderRequestID).ExecRequestVoid(req); err != nil {
+		return nil, nil, err
+	}
+	entries := make(chan model.MDNSEntry)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(entries)
+		defer close(errs)
+		defer rec.body.Close()
+		decoder := json.NewDecoder(rec.body)
+		for {
+			var entry model.MDNSEntry
+			if err := decoder.Decode(&entry); err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return entries, errs, nil
+}
+
+func (c *Client) MDNSQueryServiceTypes(ctx context.Context, domain string, timeWindow time.Duration, options model.MDNSQueryOptions) ([]model.MDNSServiceType, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSDiscoveryPath, model.TypesPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genMDNSQuery("", domain, timeWindow, options), nil)
+	if err != nil {
+		return nil, err
+	}
+	var types []model.MDNSServiceType
+	err = c.baseClient.ExecRequestJSON(req, &types)
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (c *Client) MDNSLookupInstance(ctx context.Context, instance, service, domain string, timeWindow time.Duration, options model.MDNSQueryOptions) (model.MDNSEntry, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSDiscoveryPath, model.InstancePath)
+	if err != nil {
+		return model.MDNSEntry{}, err
+	}
+	query := genMDNSQuery(service, domain, timeWindow, options)
+	if query == "" {
+		query = "?"
+	} else {
+		query += "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+query+"instance="+url.QueryEscape(instance), nil)
+	if err != nil {
+		return model.MDNSEntry{}, err
+	}
+	var entry model.MDNSEntry
+	err = c.baseClient.ExecRequestJSON(req, &entry)
+	if err != nil {
+		return model.MDNSEntry{}, err
+	}
+	return entry, nil
+}
+
+func genMDNSQuery(srv, dom string, tw time.Duration, options model.MDNSQueryOptions) string {
 	var items []string
 	if srv != "" {
 		items = append(items, fmt.Sprintf("service=%s", srv))
@@ -54,8 +145,39 @@ func genMDNSQuery(srv, dom string, tw time.Duration) string {
 	if tw > 0 {
 		items = append(items, fmt.Sprintf("time_window=%d", tw.Nanoseconds()))
 	}
+	if options.Fresh {
+		items = append(items, "fresh=true")
+	}
+	for _, itf := range options.Interfaces {
+		items = append(items, "interfaces="+url.QueryEscape(itf))
+	}
+	for _, item := range options.Txt {
+		items = append(items, "txt="+url.QueryEscape(item))
+	}
+	if options.IPType != "" {
+		items = append(items, "ip_type="+url.QueryEscape(options.IPType))
+	}
 	if len(items) > 0 {
 		return "?" + strings.Join(items, "&")
 	}
 	return ""
 }
+
+// bodyRecorder takes the body of successful responses, so streams can be read while errors are still handled by the
+// base client.
+type bodyRecorder struct {
+	httpClient base_client.HTTPClient
+	body       io.ReadCloser
+}
+
+func (r *bodyRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		r.body = resp.Body
+		resp.Body = http.NoBody
+	}
+	return resp, nil
+}
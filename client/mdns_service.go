@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (c *Client) ListMDNSServices(ctx context.Context) ([]model.MDNSService, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSServicesPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var services []model.MDNSService
+	err = c.baseClient.ExecRequestJSON(req, &services)
+	if err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+func (c *Client) GetMDNSService(ctx context.Context, id string) (model.MDNSService, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSServicesPath, id)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	var service model.MDNSService
+	err = c.baseClient.ExecRequestJSON(req, &service)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	return service, nil
+}
+
+func (c *Client) AddMDNSService(ctx context.Context, base model.MDNSServiceBase, owner string, ttl time.Duration) (model.MDNSService, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSServicesPath)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	return c.execMDNSServiceRequest(ctx, http.MethodPost, u, model.MDNSServiceRequest{
+		MDNSServiceBase: base,
+		Owner:           owner,
+		TTL:             int64(ttl / time.Second),
+	})
+}
+
+func (c *Client) RenewMDNSService(ctx context.Context, id, owner string, ttl time.Duration) (model.MDNSService, error) {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSServicesPath, id, model.LeasePath)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	return c.execMDNSServiceRequest(ctx, http.MethodPatch, u, model.MDNSLeaseRequest{Owner: owner, TTL: int64(ttl / time.Second)})
+}
+
+func (c *Client) RemoveMDNSService(ctx context.Context, id, owner string) error {
+	u, err := url.JoinPath(c.baseUrl, model.MDNSServicesPath, id)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u+"?owner="+url.QueryEscape(owner), nil)
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
+
+func (c *Client) execMDNSServiceRequest(ctx context.Context, method, u string, v any) (model.MDNSService, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewBuffer(body))
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	var service model.MDNSService
+	err = c.baseClient.ExecRequestJSON(req, &service)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	return service, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) ListNetScanJobs(ctx context.Context, owner string) ([]model.NetScanJob, error) {
+	u, err := url.JoinPath(c.baseUrl, model.NetScanJobsPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+"?owner="+url.QueryEscape(owner), nil)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []model.NetScanJob
+	err = c.baseClient.ExecRequestJSON(req, &jobs)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetNetScanJob(ctx context.Context, id, owner string) (model.NetScanJob, error) {
+	u, err := url.JoinPath(c.baseUrl, model.NetScanJobsPath, id)
+	if err != nil {
+		return model.NetScanJob{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+"?owner="+url.QueryEscape(owner), nil)
+	if err != nil {
+		return model.NetScanJob{}, err
+	}
+	var job model.NetScanJob
+	err = c.baseClient.ExecRequestJSON(req, &job)
+	if err != nil {
+		return model.NetScanJob{}, err
+	}
+	return job, nil
+}
+
+func (c *Client) StartNetScan(ctx context.Context, scanReq model.NetScanRequest) (string, error) {
+	u, err := url.JoinPath(c.baseUrl, model.NetScanJobsPath)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(scanReq)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	return c.baseClient.ExecRequestString(req)
+}
+
+func (c *Client) CancelNetScanJob(ctx context.Context, id, owner string) error {
+	u, err := url.JoinPath(c.baseUrl, model.NetScanJobsPath, id)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u+"?owner="+url.QueryEscape(owner), nil)
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
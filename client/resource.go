@@ -17,10 +17,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 func (c *Client) ListHostResources(ctx context.Context, filter model.HostResourceFilter) ([]model.HostResource, error) {
@@ -28,8 +31,7 @@ func (c *Client) ListHostResources(ctx context.Context, filter model.HostResourc
 	if err != nil {
 		return nil, err
 	}
-	u += genGetHostResourcesQuery(filter)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genGetHostResourcesQuery(filter, nil), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +43,42 @@ func (c *Client) ListHostResources(ctx context.Context, filter model.HostResourc
 	return resources, nil
 }
 
+// ListHostResourcesWithOptions returns the page of resources selected by the options, an error for each resource
+// type that failed and the total number of resources.
+func (c *Client) ListHostResourcesWithOptions(ctx context.Context, filter model.HostResourceFilter, options model.HostResourceListOptions) (model.HostResourceList, int, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath)
+	if err != nil {
+		return model.HostResourceList{}, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genGetHostResourcesQuery(filter, &options), nil)
+	if err != nil {
+		return model.HostResourceList{}, 0, err
+	}
+	var resourceList model.HostResourceList
+	total, err := c.execListRequest(req, &resourceList)
+	if err != nil {
+		return model.HostResourceList{}, 0, err
+	}
+	return resourceList, total, nil
+}
+
 func (c *Client) GetHostResource(ctx context.Context, id string) (model.HostResource, error) {
+	return c.getHostResource(ctx, id, false)
+}
+
+// GetHostResourceWithStatus works like GetHostResource but includes the availability and permission status.
+func (c *Client) GetHostResourceWithStatus(ctx context.Context, id string) (model.HostResource, error) {
+	return c.getHostResource(ctx, id, true)
+}
+
+func (c *Client) getHostResource(ctx context.Context, id string, withStatus bool) (model.HostResource, error) {
 	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, id)
 	if err != nil {
 		return model.HostResource{}, err
 	}
+	if withStatus {
+		u += "?status=true"
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return model.HostResource{}, err
@@ -58,6 +91,117 @@ func (c *Client) GetHostResource(ctx context.Context, id string) (model.HostReso
 	return resource, nil
 }
 
-func genGetHostResourcesQuery(filter model.HostResourceFilter) string {
+func (c *Client) ListHostResourceAliases(ctx context.Context) ([]model.ResourceAlias, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, model.AliasesPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var aliases []model.ResourceAlias
+	err = c.baseClient.ExecRequestJSON(req, &aliases)
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (c *Client) ReserveHostResource(ctx context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error) {
+	return c.execReservationRequest(ctx, http.MethodPost, rID, owner, ttl)
+}
+
+func (c *Client) RenewHostResourceReservation(ctx context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error) {
+	return c.execReservationRequest(ctx, http.MethodPatch, rID, owner, ttl)
+}
+
+func (c *Client) ReleaseHostResourceReservation(ctx context.Context, rID, owner string) error {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, rID, model.ReservationPath)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u+"?owner="+url.QueryEscape(owner), nil)
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
+
+func (c *Client) execReservationRequest(ctx context.Context, method, rID, owner string, ttl time.Duration) (model.ResourceReservation, error) {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, rID, model.ReservationPath)
+	if err != nil {
+		return model.ResourceReservation{}, err
+	}
+	body, err := json.Marshal(model.ResourceReservationRequest{
+		Owner: owner,
+		TTL:   int64(ttl / time.Second),
+	})
+	if err != nil {
+		return model.ResourceReservation{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewBuffer(body))
+	if err != nil {
+		return model.ResourceReservation{}, err
+	}
+	var reservation model.ResourceReservation
+	err = c.baseClient.ExecRequestJSON(req, &reservation)
+	if err != nil {
+		return model.ResourceReservation{}, err
+	}
+	return reservation, nil
+}
+
+func (c *Client) SetHostResourceAnnotation(ctx context.Context, rID string, annotation model.ResourceAnnotation) error {
+	return c.execAnnotationRequest(ctx, http.MethodPut, rID, annotation)
+}
+
+func (c *Client) UpdateHostResourceAnnotation(ctx context.Context, rID string, patch model.ResourceAnnotationPatch) error {
+	return c.execAnnotationRequest(ctx, http.MethodPatch, rID, patch)
+}
+
+func (c *Client) RemoveHostResourceAnnotation(ctx context.Context, rID string) error {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, rID, model.AnnotationsPath)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
+
+func (c *Client) execAnnotationRequest(ctx context.Context, method, rID string, v any) error {
+	u, err := url.JoinPath(c.baseUrl, model.HostResourcesPath, rID, model.AnnotationsPath)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	return c.baseClient.ExecRequestVoid(req)
+}
+
+func genGetHostResourcesQuery(filter model.HostResourceFilter, options *model.HostResourceListOptions) string {
+	q := url.Values{}
+	for _, tag := range filter.Tags {
+		q.Add("tags", tag)
+	}
+	if options != nil {
+		q.Set("envelope", "true")
+		if options.Status {
+			q.Set("status", "true")
+		}
+		setListQuery(q, options.ListOptions)
+	}
+	if len(q) > 0 {
+		return "?" + q.Encode()
+	}
 	return ""
 }
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func (c *Client) SSDPQuery(ctx context.Context, target string, timeWindow time.Duration, withDescription bool) ([]model.SSDPDevice, error) {
+	u, err := url.JoinPath(c.baseUrl, model.SSDPDiscoveryPath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+genSSDPQuery(target, timeWindow, withDescription), nil)
+	if err != nil {
+		return nil, err
+	}
+	var devices []model.SSDPDevice
+	err = c.baseClient.ExecRequestJSON(req, &devices)
+	if err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+func genSSDPQuery(target string, tw time.Duration, withDescription bool) string {
+	var items []string
+	if target != "" {
+		items = append(items, "target="+url.QueryEscape(target))
+	}
+	if tw > 0 {
+		items = append(items, fmt.Sprintf("time_window=%d", tw.Nanoseconds()))
+	}
+	if withDescription {
+		items = append(items, "description=true")
+	}
+	if len(items) > 0 {
+		return "?" + strings.Join(items, "&")
+	}
+	return ""
+}
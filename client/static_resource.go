@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) GetStaticResourcesStatus(ctx context.Context) (model.StaticResourcesStatus, error) {
+	u, err := url.JoinPath(c.baseUrl, model.StaticResPath)
+	if err != nil {
+		return model.StaticResourcesStatus{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.StaticResourcesStatus{}, err
+	}
+	var status model.StaticResourcesStatus
+	err = c.baseClient.ExecRequestJSON(req, &status)
+	if err != nil {
+		return model.StaticResourcesStatus{}, err
+	}
+	return status, nil
+}
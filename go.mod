@@ -20,6 +20,7 @@ require (
 	github.com/swaggo/swag v1.16.4
 	github.com/y-du/go-env-loader v0.5.2
 	github.com/y-du/go-log-level v1.0.0
+	golang.org/x/net v0.34.0
 )
 
 require (
@@ -52,7 +53,6 @@ require (
 	golang.org/x/arch v0.13.0 // indirect
 	golang.org/x/crypto v0.32.0 // indirect
 	golang.org/x/mod v0.22.0 // indirect
-	golang.org/x/net v0.34.0 // indirect
 	golang.org/x/sync v0.10.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
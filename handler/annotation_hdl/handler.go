@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package annotation_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+type Handler struct {
+	annotations map[string]model.ResourceAnnotation
+	path        string
+	mu          sync.RWMutex
+}
+
+func New(p string) (*Handler, error) {
+	if !path.IsAbs(p) {
+		return nil, fmt.Errorf("path '%s' not absolute", p)
+	}
+	return &Handler{
+		path:        p,
+		annotations: make(map[string]model.ResourceAnnotation),
+	}, nil
+}
+
+func (h *Handler) Init() error {
+	var annotations map[string]model.ResourceAnnotation
+	if err := json_sto_file.Read(h.path, &annotations); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if annotations != nil {
+		h.annotations = annotations
+	}
+	return nil
+}
+
+func (h *Handler) List(_ context.Context) (map[string]model.ResourceAnnotation, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	annotations := make(map[string]model.ResourceAnnotation)
+	for id, annotation := range h.annotations {
+		annotations[id] = annotation
+	}
+	return annotations, nil
+}
+
+func (h *Handler) Set(_ context.Context, rID string, annotation model.ResourceAnnotation) error {
+	tags, err := cleanTags(annotation.Tags)
+	if err != nil {
+		return err
+	}
+	annotation.Name = strings.TrimSpace(annotation.Name)
+	annotation.Tags = tags
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.store(rID, &annotation)
+}
+
+func (h *Handler) Update(_ context.Context, rID string, patch model.ResourceAnnotationPatch) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	annotation := h.annotations[rID]
+	if patch.Name != nil {
+		annotation.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Tags != nil {
+		tags, err := cleanTags(patch.Tags)
+		if err != nil {
+			return err
+		}
+		annotation.Tags = tags
+	}
+	return h.store(rID, &annotation)
+}
+
+func (h *Handler) Remove(_ context.Context, rID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.annotations[rID]; !ok {
+		return model.NewNotFoundError(fmt.Errorf("no annotations for resource '%s'", rID))
+	}
+	return h.store(rID, nil)
+}
+
+func (h *Handler) store(rID string, annotation *model.ResourceAnnotation) error {
+	newAnnotations := make(map[string]model.ResourceAnnotation)
+	for id, a := range h.annotations {
+		if id != rID {
+			newAnnotations[id] = a
+		}
+	}
+	if annotation != nil {
+		newAnnotations[rID] = *annotation
+	}
+	if err := json_sto_file.Write(newAnnotations, h.path, true); err != nil {
+		return model.NewInternalError(err)
+	}
+	h.annotations = newAnnotations
+	return nil
+}
+
+func cleanTags(tags []string) ([]string, error) {
+	var cleaned []string
+	set := make(map[string]struct{})
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, model.NewInvalidInputError(errors.New("empty tag"))
+		}
+		if _, ok := set[tag]; ok {
+			continue
+		}
+		set[tag] = struct{}{}
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned, nil
+}
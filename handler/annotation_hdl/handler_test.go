@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package annotation_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Init(t *testing.T) {
+	tmpFilePath := path.Join(t.TempDir(), "test.json")
+	t.Run("file does not exist", func(t *testing.T) {
+		h, err := New(tmpFilePath)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err != nil {
+			t.Error(err)
+		}
+	})
+	a := map[string]model.ResourceAnnotation{
+		"serial:123": {Name: "meter-bus-1", Tags: []string{"location:cabinet-A"}},
+	}
+	t.Run("file exists", func(t *testing.T) {
+		h, err := New(tmpFilePath)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Set(context.Background(), "serial:123", a["serial:123"]); err != nil {
+			t.Fatal(err)
+		}
+		h2, err := New(tmpFilePath)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h2.Init(); err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(a, h2.annotations) {
+			t.Errorf("got %+v, expected %+v", h2.annotations, a)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		f, err := os.Create(tmpFilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		h, err := New(tmpFilePath)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestHandler_Set(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"))
+	if err != nil {
+		t.Error(err)
+	}
+	err = h.Set(context.Background(), "serial:123", model.ResourceAnnotation{
+		Name: " meter-bus-1 ",
+		Tags: []string{"a", " b", "a"},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	a := model.ResourceAnnotation{Name: "meter-bus-1", Tags: []string{"a", "b"}}
+	if b := h.annotations["serial:123"]; !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+	t.Run("empty tag", func(t *testing.T) {
+		err = h.Set(context.Background(), "serial:123", model.ResourceAnnotation{Tags: []string{""}})
+		var ii *model.InvalidInputError
+		if !errors.As(err, &ii) {
+			t.Error("expected InvalidInputError")
+		}
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"))
+	if err != nil {
+		t.Error(err)
+	}
+	name := "meter-bus-1"
+	if err = h.Update(context.Background(), "serial:123", model.ResourceAnnotationPatch{Name: &name}); err != nil {
+		t.Error(err)
+	}
+	if err = h.Update(context.Background(), "serial:123", model.ResourceAnnotationPatch{Tags: []string{"a"}}); err != nil {
+		t.Error(err)
+	}
+	a := model.ResourceAnnotation{Name: "meter-bus-1", Tags: []string{"a"}}
+	if b := h.annotations["serial:123"]; !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
+
+func TestHandler_Remove(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"))
+	if err != nil {
+		t.Error(err)
+	}
+	t.Run("doesn't exist", func(t *testing.T) {
+		err = h.Remove(context.Background(), "serial:123")
+		var nf *model.NotFoundError
+		if !errors.As(err, &nf) {
+			t.Error("expected NotFoundError")
+		}
+	})
+	h.annotations["serial:123"] = model.ResourceAnnotation{}
+	if err = h.Remove(context.Background(), "serial:123"); err != nil {
+		t.Error(err)
+	}
+	if len(h.annotations) != 0 {
+		t.Error("expected empty map")
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package list_query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+type query struct {
+	Sort   string   `form:"sort"`
+	Order  string   `form:"order"`
+	Limit  int      `form:"limit"`
+	Offset int      `form:"offset"`
+	Fields []string `form:"fields"`
+}
+
+// SortFuncs maps sort keys to comparison functions.
+type SortFuncs[T any] map[string]func(a, b T) int
+
+// Parse binds the list query parameters.
+func Parse(gc *gin.Context) (lib_model.ListOptions, error) {
+	q := query{}
+	if err := gc.ShouldBindQuery(&q); err != nil {
+		return lib_model.ListOptions{}, err
+	}
+	if q.Limit < 0 {
+		return lib_model.ListOptions{}, errors.New("invalid limit")
+	}
+	if q.Offset < 0 {
+		return lib_model.ListOptions{}, errors.New("invalid offset")
+	}
+	switch q.Order {
+	case "", lib_model.OrderAsc, lib_model.OrderDesc:
+	default:
+		return lib_model.ListOptions{}, fmt.Errorf("invalid order '%s'", q.Order)
+	}
+	return lib_model.ListOptions{
+		Sort:   q.Sort,
+		Order:  q.Order,
+		Limit:  q.Limit,
+		Offset: q.Offset,
+		Fields: q.Fields,
+	}, nil
+}
+
+// Apply sorts the items by the given key or the default key and returns the requested page. Items with equal keys
+// keep their order. The total count is the number of items before pagination.
+func Apply[T any](items []T, options lib_model.ListOptions, sortFuncs SortFuncs[T], defaultKey string) ([]T, int, error) {
+	key := options.Sort
+	if key == "" {
+		key = defaultKey
+	}
+	cmp, ok := sortFuncs[key]
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid sort key '%s'", key)
+	}
+	items = slices.Clone(items)
+	slices.SortStableFunc(items, cmp)
+	if options.Order == lib_model.OrderDesc {
+		slices.Reverse(items)
+	}
+	total := len(items)
+	if options.Offset >= total {
+		return []T{}, total, nil
+	}
+	items = items[options.Offset:]
+	if options.Limit > 0 && options.Limit < len(items) {
+		items = items[:options.Limit]
+	}
+	return items, total, nil
+}
+
+// SelectFields returns the JSON objects of the items reduced to the given fields. Items are returned unchanged if no
+// fields are given.
+func SelectFields[T any](items []T, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+	objects := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var object map[string]json.RawMessage
+		if err = json.Unmarshal(b, &object); err != nil {
+			return nil, fmt.Errorf("field selection not supported: %s", err)
+		}
+		selected := make(map[string]json.RawMessage)
+		for _, field := range fields {
+			v, ok := object[field]
+			if !ok {
+				return nil, fmt.Errorf("invalid field '%s'", field)
+			}
+			selected[field] = v
+		}
+		objects = append(objects, selected)
+	}
+	return objects, nil
+}
+
+// Page parses the list query parameters, applies them to the items and sets the total count header. Errors are
+// added to the context, in which case false is returned.
+func Page[T any](gc *gin.Context, items []T, sortFuncs SortFuncs[T], defaultKey string) (any, bool) {
+	options, err := Parse(gc)
+	if err != nil {
+		_ = gc.Error(lib_model.NewInvalidInputError(err))
+		return nil, false
+	}
+	page, total, err := Apply(items, options, sortFuncs, defaultKey)
+	if err != nil {
+		_ = gc.Error(lib_model.NewInvalidInputError(err))
+		return nil, false
+	}
+	body, err := SelectFields(page, options.Fields)
+	if err != nil {
+		_ = gc.Error(lib_model.NewInvalidInputError(err))
+		return nil, false
+	}
+	gc.Header(lib_model.HeaderTotalCount, strconv.Itoa(total))
+	return body, true
+}
+
+// Respond writes the page of items selected by the list query parameters as JSON.
+func Respond[T any](gc *gin.Context, items []T, sortFuncs SortFuncs[T], defaultKey string) {
+	if body, ok := Page(gc, items, sortFuncs, defaultKey); ok {
+		gc.JSON(http.StatusOK, body)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package list_query
+
+import (
+	"encoding/json"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+var testSortFuncs = SortFuncs[testItem]{
+	"id": func(a, b testItem) int {
+		return strings.Compare(a.ID, b.ID)
+	},
+	"name": func(a, b testItem) int {
+		return strings.Compare(a.Name, b.Name)
+	},
+}
+
+var testItems = []testItem{
+	{ID: "b", Name: "x"},
+	{ID: "c", Name: "y"},
+	{ID: "a", Name: "z"},
+}
+
+func TestApply(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		items, total, err := Apply(testItems, lib_model.ListOptions{}, testSortFuncs, "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 || !reflect.DeepEqual(items, []testItem{testItems[2], testItems[0], testItems[1]}) {
+			t.Errorf("unexpected result %v %d", items, total)
+		}
+		if testItems[0].ID != "b" {
+			t.Error("input modified")
+		}
+	})
+	t.Run("sort desc paged", func(t *testing.T) {
+		items, total, err := Apply(testItems, lib_model.ListOptions{Sort: "name", Order: lib_model.OrderDesc, Limit: 1, Offset: 1}, testSortFuncs, "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 || !reflect.DeepEqual(items, []testItem{testItems[1]}) {
+			t.Errorf("unexpected result %v %d", items, total)
+		}
+	})
+	t.Run("offset out of range", func(t *testing.T) {
+		items, total, err := Apply(testItems, lib_model.ListOptions{Offset: 5}, testSortFuncs, "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 || len(items) != 0 {
+			t.Errorf("unexpected result %v %d", items, total)
+		}
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		if _, _, err := Apply(testItems, lib_model.ListOptions{Sort: "test"}, testSortFuncs, "id"); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestRespond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newContext := func(q string) (*gin.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		gc, _ := gin.CreateTestContext(rec)
+		gc.Request = httptest.NewRequest(http.MethodGet, "/?"+q, nil)
+		return gc, rec
+	}
+	t.Run("fields", func(t *testing.T) {
+		gc, rec := newContext("sort=name&limit=2&fields=id")
+		Respond(gc, testItems, testSortFuncs, "id")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d", rec.Code)
+		}
+		if rec.Header().Get(lib_model.HeaderTotalCount) != "3" {
+			t.Errorf("unexpected total count '%s'", rec.Header().Get(lib_model.HeaderTotalCount))
+		}
+		var body []map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, []map[string]string{{"id": "b"}, {"id": "c"}}) {
+			t.Errorf("unexpected body %v", body)
+		}
+	})
+	for _, q := range []string{"limit=-1", "offset=x", "order=test", "sort=test", "fields=test"} {
+		t.Run(q, func(t *testing.T) {
+			gc, _ := newContext(q)
+			Respond(gc, testItems, testSortFuncs, "id")
+			if len(gc.Errors) != 1 {
+				t.Error("expected error")
+			}
+		})
+	}
+	t.Run("fields on strings", func(t *testing.T) {
+		gc, _ := newContext("fields=value")
+		Respond(gc, []string{"a"}, SortFuncs[string]{"value": strings.Compare}, "value")
+		if len(gc.Errors) != 1 {
+			t.Error("expected error")
+		}
+	})
+}
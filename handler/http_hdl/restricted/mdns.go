@@ -17,27 +17,51 @@
 package restricted
 
 import (
+	"encoding/json"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"path"
 	"time"
 )
 
 type mdnsQuery struct {
-	Service    string `form:"service"`
-	Domain     string `form:"domain"`
-	TimeWindow int64  `form:"time_window"`
+	Service    string   `form:"service"`
+	Domain     string   `form:"domain"`
+	TimeWindow int64    `form:"time_window"`
+	Fresh      bool     `form:"fresh"`
+	Interfaces []string `form:"interfaces"`
+	IPType     string   `form:"ip_type"`
+	Txt        []string `form:"txt"`
+}
+
+type mdnsInstanceQuery struct {
+	mdnsQuery
+	Instance string `form:"instance"`
+}
+
+func (q mdnsQuery) options() lib_model.MDNSQueryOptions {
+	return lib_model.MDNSQueryOptions{
+		Fresh:      q.Fresh,
+		Interfaces: q.Interfaces,
+		IPType:     q.IPType,
+		Txt:        q.Txt,
+	}
 }
 
 // GetMDNSQueryH godoc
 // @Summary MDNS query
-// @Description	Query MDNS devices on attached networks.
+// @Description	Query MDNS devices on attached networks. Services browsed in the background are answered from the cache unless fresh is set.
 // @Tags MDNS
 // @Produce	json
 // @Param service query string true "MDNS service string (e.g.: '_services._dns-sd._udp' for all available services)"
 // @Param domain query string false "limit the query to a domain"
 // @Param time_window query int false "set the maximum duration for the query (defaults to 1s)"
+// @Param interfaces query []string false "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)" collectionFormat(multi)
+// @Param ip_type query string false "IP traffic to use (defaults to ipv4)" Enums(ipv4, ipv6, both)
+// @Param txt query []string false "only include services with matching TXT records, 'key' requires the key and 'key=value' the value (e.g.: 'vendor=acme')" collectionFormat(multi)
+// @Param fresh query bool false "browse the network even if the service is cached"
 // @Success	200 {array} lib_model.MDNSEntry "list of services"
 // @Failure	400 {string} string "error message"
 // @Failure	500 {string} string "error message"
@@ -52,7 +76,7 @@ func GetMDNSQueryH(a lib.Api) (string, string, gin.HandlerFunc) {
 		if query.TimeWindow == 0 {
 			query.TimeWindow = int64(time.Second)
 		}
-		results, err := a.MDNSQueryService(gc.Request.Context(), query.Service, query.Domain, time.Duration(query.TimeWindow))
+		results, err := a.MDNSQueryService(gc.Request.Context(), query.Service, query.Domain, time.Duration(query.TimeWindow), query.options())
 		if err != nil {
 			_ = gc.Error(err)
 			return
@@ -60,3 +84,115 @@ func GetMDNSQueryH(a lib.Api) (string, string, gin.HandlerFunc) {
 		gc.JSON(http.StatusOK, results)
 	}
 }
+
+// GetMDNSQueryStreamH godoc
+// @Summary MDNS query stream
+// @Description	Query MDNS devices on attached networks and receive each entry as soon as it is discovered. Entries are sent as newline delimited JSON until the time window ends or the client disconnects.
+// @Tags MDNS
+// @Produce	application/x-ndjson
+// @Param service query string true "MDNS service string (e.g.: '_services._dns-sd._udp' for all available services)"
+// @Param domain query string false "limit the query to a domain"
+// @Param time_window query int false "set the maximum duration for the query (defaults to 1s)"
+// @Param interfaces query []string false "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)" collectionFormat(multi)
+// @Param ip_type query string false "IP traffic to use (defaults to ipv4)" Enums(ipv4, ipv6, both)
+// @Param txt query []string false "only include services with matching TXT records, 'key' requires the key and 'key=value' the value (e.g.: 'vendor=acme')" collectionFormat(multi)
+// @Success	200 {object} lib_model.MDNSEntry "stream of services"
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-discovery/stream [get]
+func GetMDNSQueryStreamH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.MDNSDiscoveryPath, lib_model.StreamPath), func(gc *gin.Context) {
+		var query mdnsQuery
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if query.TimeWindow == 0 {
+			query.TimeWindow = int64(time.Second)
+		}
+		entries, err := a.MDNSQueryServiceStream(gc.Request.Context(), query.Service, query.Domain, time.Duration(query.TimeWindow), query.options())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Header("Content-Type", lib_model.ContentTypeNDJSON)
+		gc.Status(http.StatusOK)
+		gc.Writer.Flush()
+		encoder := json.NewEncoder(gc.Writer)
+		for entry := range entries {
+			// keep draining after write errors, the channel is closed once the request context is done
+			if err = encoder.Encode(entry); err != nil {
+				continue
+			}
+			gc.Writer.Flush()
+		}
+	}
+}
+
+// GetMDNSServiceTypesH godoc
+// @Summary MDNS service types
+// @Description	List the distinct service types announced on attached networks.
+// @Tags MDNS
+// @Produce	json
+// @Param domain query string false "limit the query to a domain"
+// @Param time_window query int false "set the maximum duration for the query (defaults to 1s)"
+// @Param fresh query bool false "browse the network even if the service types are cached"
+// @Param interfaces query []string false "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)" collectionFormat(multi)
+// @Param ip_type query string false "IP traffic to use (defaults to ipv4)" Enums(ipv4, ipv6, both)
+// @Success	200 {array} lib_model.MDNSServiceType "service types"
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-discovery/types [get]
+func GetMDNSServiceTypesH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.MDNSDiscoveryPath, lib_model.TypesPath), func(gc *gin.Context) {
+		var query mdnsQuery
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if query.TimeWindow == 0 {
+			query.TimeWindow = int64(time.Second)
+		}
+		types, err := a.MDNSQueryServiceTypes(gc.Request.Context(), query.Domain, time.Duration(query.TimeWindow), query.options())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, types)
+	}
+}
+
+// GetMDNSInstanceH godoc
+// @Summary MDNS instance lookup
+// @Description	Resolve a single service instance on attached networks. Returns as soon as the instance responded.
+// @Tags MDNS
+// @Produce	json
+// @Param instance query string true "instance name"
+// @Param service query string true "MDNS service string (e.g.: '_http._tcp')"
+// @Param domain query string false "limit the query to a domain"
+// @Param time_window query int false "set the maximum duration for the lookup (defaults to 1s)"
+// @Param interfaces query []string false "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)" collectionFormat(multi)
+// @Param ip_type query string false "IP traffic to use (defaults to ipv4)" Enums(ipv4, ipv6, both)
+// @Success	200 {object} lib_model.MDNSEntry "service"
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-discovery/instance [get]
+func GetMDNSInstanceH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.MDNSDiscoveryPath, lib_model.InstancePath), func(gc *gin.Context) {
+		var query mdnsInstanceQuery
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if query.TimeWindow == 0 {
+			query.TimeWindow = int64(time.Second)
+		}
+		entry, err := a.MDNSLookupInstance(gc.Request.Context(), query.Instance, query.Service, query.Domain, time.Duration(query.TimeWindow), query.options())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, entry)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restricted
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"path"
+	"time"
+)
+
+type removeMDNSServiceQuery struct {
+	Owner string `form:"owner"`
+}
+
+// GetMDNSServicesH godoc
+// @Summary List MDNS services
+// @Description	List services announced via MDNS.
+// @Tags MDNS
+// @Produce	json
+// @Success	200 {array} lib_model.MDNSService "services"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-services [get]
+func GetMDNSServicesH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, lib_model.MDNSServicesPath, func(gc *gin.Context) {
+		services, err := a.ListMDNSServices(gc.Request.Context())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, services)
+	}
+}
+
+// GetMDNSServiceH godoc
+// @Summary Get MDNS service
+// @Description	Get a service announced via MDNS.
+// @Tags MDNS
+// @Produce	json
+// @Param id path string true "service id"
+// @Success	200 {object} lib_model.MDNSService "service"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-services/{id} [get]
+func GetMDNSServiceH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.MDNSServicesPath, ":id"), func(gc *gin.Context) {
+		service, err := a.GetMDNSService(gc.Request.Context(), gc.Param("id"))
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, service)
+	}
+}
+
+// PostMDNSServiceH godoc
+// @Summary Add MDNS service
+// @Description	Announce a service via MDNS for an owner until the lease expires. Leases must be renewed by the owner before they expire and are kept across restarts.
+// @Tags MDNS
+// @Accept json
+// @Produce	json
+// @Param service body lib_model.MDNSServiceRequest true "service information, owner and lease ttl"
+// @Success	200 {object} lib_model.MDNSService "service"
+// @Failure	400 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-services [post]
+func PostMDNSServiceH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, lib_model.MDNSServicesPath, func(gc *gin.Context) {
+		var req lib_model.MDNSServiceRequest
+		if err := gc.ShouldBindJSON(&req); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		ttl, err := ttlDuration(req.TTL)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		service, err := a.AddMDNSService(gc.Request.Context(), req.MDNSServiceBase, req.Owner, ttl)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, service)
+	}
+}
+
+// PatchMDNSServiceLeaseH godoc
+// @Summary Renew MDNS service lease
+// @Description	Renew the lease of a service announced via MDNS. Only the owner can renew the lease.
+// @Tags MDNS
+// @Accept json
+// @Produce	json
+// @Param id path string true "service id"
+// @Param lease body lib_model.MDNSLeaseRequest true "owner and lease ttl"
+// @Success	200 {object} lib_model.MDNSService "service"
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-services/{id}/lease [patch]
+func PatchMDNSServiceLeaseH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPatch, path.Join(lib_model.MDNSServicesPath, ":id", lib_model.LeasePath), func(gc *gin.Context) {
+		var req lib_model.MDNSLeaseRequest
+		if err := gc.ShouldBindJSON(&req); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		ttl, err := ttlDuration(req.TTL)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		service, err := a.RenewMDNSService(gc.Request.Context(), gc.Param("id"), req.Owner, ttl)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, service)
+	}
+}
+
+// DeleteMDNSServiceH godoc
+// @Summary Remove MDNS service
+// @Description	Stop announcing a service and remove it. Only the owner can remove a service.
+// @Tags MDNS
+// @Param id path string true "service id"
+// @Param owner query string true "service owner"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /mdns-services/{id} [delete]
+func DeleteMDNSServiceH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodDelete, path.Join(lib_model.MDNSServicesPath, ":id"), func(gc *gin.Context) {
+		query := removeMDNSServiceQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if err := a.RemoveMDNSService(gc.Request.Context(), gc.Param("id"), query.Owner); err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// ttlDuration converts a lease ttl in seconds to a duration and rejects values that would overflow.
+func ttlDuration(ttl int64) (time.Duration, error) {
+	if ttl > math.MaxInt64/int64(time.Second) {
+		return 0, lib_model.NewInvalidInputError(errors.New("invalid ttl"))
+	}
+	return time.Duration(ttl) * time.Second, nil
+}
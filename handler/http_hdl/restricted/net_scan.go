@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restricted
+
+import (
+	"github.com/SENERGY-Platform/mgw-host-manager/lib"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path"
+)
+
+type netScanJobQuery struct {
+	Owner string `form:"owner"`
+}
+
+// GetNetScanJobsH godoc
+// @Summary List network scan jobs
+// @Description	List running and recently finished network scan jobs of an owner.
+// @Tags Network Scan
+// @Produce	json
+// @Param owner query string true "job owner"
+// @Success	200 {array} lib_model.NetScanJob "jobs"
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /net-scan-jobs [get]
+func GetNetScanJobsH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, lib_model.NetScanJobsPath, func(gc *gin.Context) {
+		query := netScanJobQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		jobs, err := a.ListNetScanJobs(gc.Request.Context(), query.Owner)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, jobs)
+	}
+}
+
+// GetNetScanJobH godoc
+// @Summary Get network scan job
+// @Description	Get the progress and the hosts with open ports found so far. Only the owner can get a job.
+// @Tags Network Scan
+// @Produce	json
+// @Param id path string true "job id"
+// @Param owner query string true "job owner"
+// @Success	200 {object} lib_model.NetScanJob "job"
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /net-scan-jobs/{id} [get]
+func GetNetScanJobH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.NetScanJobsPath, ":id"), func(gc *gin.Context) {
+		query := netScanJobQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		job, err := a.GetNetScanJob(gc.Request.Context(), gc.Param("id"), query.Owner)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, job)
+	}
+}
+
+// PostNetScanJobH godoc
+// @Summary Start network scan
+// @Description	Start a rate limited TCP connect scan of a network. The network must belong to an interface not hidden by blacklists, hosts in blacklisted ranges are skipped. The number of running jobs is limited.
+// @Tags Network Scan
+// @Accept json
+// @Produce	plain
+// @Param request body lib_model.NetScanRequest true "network, ports and owner"
+// @Success	200 {string} string "job ID"
+// @Failure	400 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /net-scan-jobs [post]
+func PostNetScanJobH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, lib_model.NetScanJobsPath, func(gc *gin.Context) {
+		var req lib_model.NetScanRequest
+		if err := gc.ShouldBindJSON(&req); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		id, err := a.StartNetScan(gc.Request.Context(), req)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.String(http.StatusOK, id)
+	}
+}
+
+// DeleteNetScanJobH godoc
+// @Summary Cancel network scan job
+// @Description	Cancel a running job. Finished jobs are removed. Only the owner can cancel a job.
+// @Tags Network Scan
+// @Param id path string true "job id"
+// @Param owner query string true "job owner"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /net-scan-jobs/{id} [delete]
+func DeleteNetScanJobH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodDelete, path.Join(lib_model.NetScanJobsPath, ":id"), func(gc *gin.Context) {
+		query := netScanJobQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if err := a.CancelNetScanJob(gc.Request.Context(), gc.Param("id"), query.Owner); err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
@@ -30,6 +30,19 @@ import (
 
 var routes = gin_mw.Routes[lib.Api]{
 	GetMDNSQueryH,
+	GetMDNSQueryStreamH,
+	GetMDNSServiceTypesH,
+	GetMDNSInstanceH,
+	GetMDNSServicesH,
+	GetMDNSServiceH,
+	PostMDNSServiceH,
+	PatchMDNSServiceLeaseH,
+	DeleteMDNSServiceH,
+	GetSSDPQueryH,
+	GetNetScanJobsH,
+	GetNetScanJobH,
+	PostNetScanJobH,
+	DeleteNetScanJobH,
 }
 
 // SetRoutes
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restricted
+
+import (
+	"github.com/SENERGY-Platform/mgw-host-manager/lib"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ssdpQuery struct {
+	Target      string `form:"target"`
+	TimeWindow  int64  `form:"time_window"`
+	Description bool   `form:"description"`
+}
+
+// GetSSDPQueryH godoc
+// @Summary SSDP query
+// @Description	Search for UPnP devices on allowed network interfaces via SSDP. Devices in blacklisted network ranges are omitted and descriptions are only fetched from the address of the responding device.
+// @Tags SSDP
+// @Produce	json
+// @Param target query string false "search target (defaults to 'ssdp:all', e.g.: 'upnp:rootdevice')"
+// @Param time_window query int false "set the maximum duration for the query (defaults to 2s, maximum 10s)"
+// @Param description query bool false "fetch and include device descriptions"
+// @Success	200 {array} lib_model.SSDPDevice "list of devices"
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /ssdp-discovery [get]
+func GetSSDPQueryH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, lib_model.SSDPDiscoveryPath, func(gc *gin.Context) {
+		var query ssdpQuery
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		if query.TimeWindow == 0 {
+			query.TimeWindow = int64(2 * time.Second)
+		}
+		results, err := a.SSDPQuery(gc.Request.Context(), query.Target, time.Duration(query.TimeWindow), query.Description)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, results)
+	}
+}
@@ -61,3 +61,33 @@ func GetHostNetH(a lib.Api) (string, string, gin.HandlerFunc) {
 		gc.JSON(http.StatusOK, hostNet)
 	}
 }
+
+type neighborsQuery struct {
+	MACPrefixes []string `form:"mac_prefix"`
+}
+
+// GetHostNetNeighborsH godoc
+// @Summary Get network neighbors
+// @Description	List IPv4 and IPv6 neighbors from the ARP and neighbor discovery tables of the host. Neighbors on blacklisted interfaces or in blacklisted ranges are hidden.
+// @Tags Host Information
+// @Produce	json
+// @Param mac_prefix query []string false "only include neighbors with matching MAC address prefix (e.g.: 'b8:27:eb')" collectionFormat(multi)
+// @Success	200 {array} lib_model.NetNeighbor "neighbors"
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-info/network/neighbors [get]
+func GetHostNetNeighborsH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.HostInfoPath, lib_model.HostNetPath, lib_model.HostNeighborsPath), func(gc *gin.Context) {
+		var query neighborsQuery
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		neighbors, err := a.GetHostNetNeighbors(gc.Request.Context(), lib_model.NetNeighborFilter{MACPrefixes: query.MACPrefixes})
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, neighbors)
+	}
+}
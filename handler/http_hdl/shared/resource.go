@@ -17,22 +17,63 @@
 package shared
 
 import (
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/http_hdl/list_query"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
 	"path"
+	"strings"
+	"time"
 )
 
+var hostResourceSortFuncs = list_query.SortFuncs[lib_model.HostResource]{
+	"id": func(a, b lib_model.HostResource) int {
+		return strings.Compare(a.ID, b.ID)
+	},
+	"type": func(a, b lib_model.HostResource) int {
+		return strings.Compare(a.Type, b.Type)
+	},
+	"name": func(a, b lib_model.HostResource) int {
+		return strings.Compare(a.Name, b.Name)
+	},
+	"path": func(a, b lib_model.HostResource) int {
+		return strings.Compare(a.Path, b.Path)
+	},
+}
+
 type hostResourcesQuery struct {
+	Tags     []string `form:"tags"`
+	Status   bool     `form:"status"`
+	Envelope bool     `form:"envelope"`
+}
+
+type hostResourceQuery struct {
+	Status bool `form:"status"`
+}
+
+type releaseReservationQuery struct {
+	Owner string `form:"owner"`
 }
 
 // GetHostResourcesH godoc
 // @Summary List resources
-// @Description	List host resources like application sockets or serial adapters.
+// @Description	List host resources like application sockets or serial adapters. Resource types that fail or time out are omitted, with envelope=true an object containing the resources and an error for each of these types is returned.
 // @Tags Host Resources
 // @Produce	json
-// @Success	200 {array} lib_model.HostResource "host resources"
+// @Param tags query []string false "only resources with all tags" collectionFormat(multi)
+// @Param status query bool false "include availability and permission status"
+// @Param envelope query bool false "wrap resources and per type errors in an object"
+// @Param sort query string false "sort key" Enums(id, type, name, path)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param limit query int false "maximum number of items"
+// @Param offset query int false "number of items to skip"
+// @Param fields query []string false "only include the given fields" collectionFormat(multi)
+// @Success	200 {object} lib_model.HostResourceList "host resources and per type errors, without envelope only the array of host resources"
+// @Header 200 {integer} X-Total-Count "total number of items"
+// @Failure	400 {string} string "error message"
 // @Failure	500 {string} string "error message"
 // @Router /host-resources [get]
 func GetHostResourcesH(a lib.Api) (string, string, gin.HandlerFunc) {
@@ -42,27 +83,46 @@ func GetHostResourcesH(a lib.Api) (string, string, gin.HandlerFunc) {
 			_ = gc.Error(lib_model.NewInvalidInputError(err))
 			return
 		}
-		resources, err := a.ListHostResources(gc.Request.Context(), lib_model.HostResourceFilter{})
+		resourceList, err := a.ListHostResources(gc.Request.Context(), lib_model.HostResourceFilter{
+			Tags: query.Tags,
+		}, query.Status)
 		if err != nil {
 			_ = gc.Error(err)
 			return
 		}
-		gc.JSON(http.StatusOK, resources)
+		if !query.Envelope {
+			list_query.Respond(gc, resourceList.Resources, hostResourceSortFuncs, "id")
+			return
+		}
+		resources, ok := list_query.Page(gc, resourceList.Resources, hostResourceSortFuncs, "id")
+		if !ok {
+			return
+		}
+		gc.JSON(http.StatusOK, gin.H{
+			"resources": resources,
+			"errors":    resourceList.Errors,
+		})
 	}
 }
 
 // GetHostResourceH godoc
 // @Summary Get resource
-// @Description	Get a host resource.
+// @Description	Get a host resource. Previous resource IDs are resolved via aliases.
 // @Tags Host Resources
 // @Produce	json
 // @Param id path string true "resource id"
+// @Param status query bool false "include availability and permission status"
 // @Success	200 {object} lib_model.HostResource "host resources"
 // @Failure	500 {string} string "error message"
 // @Router /host-resources/{id} [get]
 func GetHostResourceH(a lib.Api) (string, string, gin.HandlerFunc) {
 	return http.MethodGet, path.Join(lib_model.HostResourcesPath, ":id"), func(gc *gin.Context) {
-		resource, err := a.GetHostResource(gc.Request.Context(), gc.Param("id"))
+		query := hostResourceQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		resource, err := a.GetHostResource(gc.Request.Context(), gc.Param("id"), query.Status)
 		if err != nil {
 			_ = gc.Error(err)
 			return
@@ -70,3 +130,127 @@ func GetHostResourceH(a lib.Api) (string, string, gin.HandlerFunc) {
 		gc.JSON(http.StatusOK, resource)
 	}
 }
+
+// GetHostResourceAliasesH godoc
+// @Summary List aliases
+// @Description	List previous resource IDs and the stable resource IDs they resolve to.
+// @Tags Host Resources
+// @Produce	json
+// @Success	200 {array} lib_model.ResourceAlias "resource aliases"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/aliases [get]
+func GetHostResourceAliasesH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.HostResourcesPath, lib_model.AliasesPath), func(gc *gin.Context) {
+		aliases, err := a.ListHostResourceAliases(gc.Request.Context())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, aliases)
+	}
+}
+
+// PostHostResourceReservationH godoc
+// @Summary Reserve resource
+// @Description	Reserve a host resource exclusively for an owner. Reserving an already held resource with the same owner renews the reservation.
+// @Tags Host Resources
+// @Accept json
+// @Produce	json
+// @Param id path string true "resource id"
+// @Param reservation body lib_model.ResourceReservationRequest true "owner and ttl"
+// @Success	200 {object} lib_model.ResourceReservation "reservation"
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/reservation [post]
+func PostHostResourceReservationH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, path.Join(lib_model.HostResourcesPath, ":id", lib_model.ReservationPath), func(gc *gin.Context) {
+		var req lib_model.ResourceReservationRequest
+		if err := gc.ShouldBindJSON(&req); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		ttl, err := ttlDuration(req.TTL)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		reservation, err := a.ReserveHostResource(gc.Request.Context(), gc.Param("id"), req.Owner, ttl)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, reservation)
+	}
+}
+
+// PatchHostResourceReservationH godoc
+// @Summary Renew reservation
+// @Description	Renew the reservation of a host resource.
+// @Tags Host Resources
+// @Accept json
+// @Produce	json
+// @Param id path string true "resource id"
+// @Param reservation body lib_model.ResourceReservationRequest true "owner and ttl"
+// @Success	200 {object} lib_model.ResourceReservation "reservation"
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/reservation [patch]
+func PatchHostResourceReservationH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPatch, path.Join(lib_model.HostResourcesPath, ":id", lib_model.ReservationPath), func(gc *gin.Context) {
+		var req lib_model.ResourceReservationRequest
+		if err := gc.ShouldBindJSON(&req); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		ttl, err := ttlDuration(req.TTL)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		reservation, err := a.RenewHostResourceReservation(gc.Request.Context(), gc.Param("id"), req.Owner, ttl)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, reservation)
+	}
+}
+
+// DeleteHostResourceReservationH godoc
+// @Summary Release reservation
+// @Description	Release the reservation of a host resource.
+// @Tags Host Resources
+// @Param id path string true "resource id"
+// @Param owner query string true "reservation owner"
+// @Success	200
+// @Failure	404 {string} string "error message"
+// @Failure	409 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/reservation [delete]
+func DeleteHostResourceReservationH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodDelete, path.Join(lib_model.HostResourcesPath, ":id", lib_model.ReservationPath), func(gc *gin.Context) {
+		query := releaseReservationQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err := a.ReleaseHostResourceReservation(gc.Request.Context(), gc.Param("id"), query.Owner)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// ttlDuration converts a ttl in seconds to a duration and rejects values that would overflow.
+func ttlDuration(ttl int64) (time.Duration, error) {
+	if ttl > math.MaxInt64/int64(time.Second) {
+		return 0, lib_model.NewInvalidInputError(errors.New("invalid ttl"))
+	}
+	return time.Duration(ttl) * time.Second, nil
+}
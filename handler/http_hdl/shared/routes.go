@@ -25,6 +25,11 @@ var Routes = gin_mw.Routes[lib.Api]{
 	GetSrvInfoH,
 	GetHostInfoH,
 	GetHostNetH,
+	GetHostNetNeighborsH,
 	GetHostResourcesH,
 	GetHostResourceH,
+	GetHostResourceAliasesH,
+	PostHostResourceReservationH,
+	PatchHostResourceReservationH,
+	DeleteHostResourceReservationH,
 }
@@ -17,19 +17,39 @@
 package standard
 
 import (
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/http_hdl/list_query"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"path"
+	"strings"
 )
 
+var hostAppSortFuncs = list_query.SortFuncs[lib_model.HostApplication]{
+	"id": func(a, b lib_model.HostApplication) int {
+		return strings.Compare(a.ID, b.ID)
+	},
+	"name": func(a, b lib_model.HostApplication) int {
+		return strings.Compare(a.Name, b.Name)
+	},
+	"socket": func(a, b lib_model.HostApplication) int {
+		return strings.Compare(a.Socket, b.Socket)
+	},
+}
+
 // GetHostApplicationsH godoc
 // @Summary List applications
 // @Description	List host applications.
 // @Tags Host Applications
 // @Produce	json
+// @Param sort query string false "sort key" Enums(id, name, socket)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param limit query int false "maximum number of items"
+// @Param offset query int false "number of items to skip"
+// @Param fields query []string false "only include the given fields" collectionFormat(multi)
 // @Success	200 {array} lib_model.HostApplication "host applications"
+// @Header 200 {integer} X-Total-Count "total number of items"
 // @Failure	500 {string} string "error message"
 // @Router /applications [get]
 func GetHostApplicationsH(a lib.Api) (string, string, gin.HandlerFunc) {
@@ -39,7 +59,7 @@ func GetHostApplicationsH(a lib.Api) (string, string, gin.HandlerFunc) {
 			_ = gc.Error(err)
 			return
 		}
-		gc.JSON(http.StatusOK, apps)
+		list_query.Respond(gc, apps, hostAppSortFuncs, "id")
 	}
 }
 
@@ -71,6 +91,64 @@ func PostHostApplicationH(a lib.Api) (string, string, gin.HandlerFunc) {
 	}
 }
 
+// PutHostApplicationH godoc
+// @Summary Update application
+// @Description	Replace the information of a host application. The application ID is kept.
+// @Tags Host Applications
+// @Accept json
+// @Param id path string true "application id"
+// @Param application body lib_model.HostApplicationBase true "application information"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /applications/{id} [put]
+func PutHostApplicationH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPut, path.Join(lib_model.HostAppsPath, ":id"), func(gc *gin.Context) {
+		var app lib_model.HostApplicationBase
+		err := gc.ShouldBindJSON(&app)
+		if err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err = a.UpdateHostApplication(gc.Request.Context(), gc.Param("id"), app)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// PatchHostApplicationH godoc
+// @Summary Patch application
+// @Description	Change individual fields of a host application, omitted fields are kept.
+// @Tags Host Applications
+// @Accept json
+// @Param id path string true "application id"
+// @Param patch body lib_model.HostApplicationPatch true "fields to change"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /applications/{id} [patch]
+func PatchHostApplicationH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPatch, path.Join(lib_model.HostAppsPath, ":id"), func(gc *gin.Context) {
+		var patch lib_model.HostApplicationPatch
+		err := gc.ShouldBindJSON(&patch)
+		if err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err = a.PatchHostApplication(gc.Request.Context(), gc.Param("id"), patch)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
 // DeleteHostApplicationH godoc
 // @Summary Delete application
 // @Description	Remove a host application.
@@ -90,3 +168,24 @@ func DeleteHostApplicationH(a lib.Api) (string, string, gin.HandlerFunc) {
 		gc.Status(http.StatusOK)
 	}
 }
+
+// GetHostApplicationHealthH godoc
+// @Summary Get application health
+// @Description	Get the result of the last health check of a host application.
+// @Tags Host Applications
+// @Produce	json
+// @Param id path string true "application id"
+// @Success	200 {object} lib_model.HostAppHealth "health check result"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /applications/{id}/health [get]
+func GetHostApplicationHealthH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.HostAppsPath, ":id", lib_model.HealthPath), func(gc *gin.Context) {
+		health, err := a.GetHostApplicationHealth(gc.Request.Context(), gc.Param("id"))
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, health)
+	}
+}
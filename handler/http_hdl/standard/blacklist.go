@@ -17,14 +17,20 @@
 package standard
 
 import (
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/http_hdl/list_query"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/gin-gonic/gin"
 	"io"
 	"net/http"
 	"path"
+	"strings"
 )
 
+var blacklistSortFuncs = list_query.SortFuncs[string]{
+	"value": strings.Compare,
+}
+
 type deleteBlacklistValQuery struct {
 	Value string `form:"value"`
 }
@@ -34,7 +40,12 @@ type deleteBlacklistValQuery struct {
 // @Description	List blacklisted host network interfaces.
 // @Tags Blacklists
 // @Produce	json
+// @Param sort query string false "sort key" Enums(value)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param limit query int false "maximum number of items"
+// @Param offset query int false "number of items to skip"
 // @Success	200 {array} string "network interfaces"
+// @Header 200 {integer} X-Total-Count "total number of items"
 // @Failure	500 {string} string "error message"
 // @Router /blacklists/net-interfaces [get]
 func GetNetItfBlacklistH(a lib.Api) (string, string, gin.HandlerFunc) {
@@ -44,7 +55,7 @@ func GetNetItfBlacklistH(a lib.Api) (string, string, gin.HandlerFunc) {
 			_ = gc.Error(err)
 			return
 		}
-		gc.JSON(http.StatusOK, values)
+		list_query.Respond(gc, values, blacklistSortFuncs, "value")
 	}
 }
 
@@ -106,7 +117,12 @@ func DeleteNetItfBlacklistValueH(a lib.Api) (string, string, gin.HandlerFunc) {
 // @Description	List blacklisted network ranges.
 // @Tags Blacklists
 // @Produce	json
+// @Param sort query string false "sort key" Enums(value)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param limit query int false "maximum number of items"
+// @Param offset query int false "number of items to skip"
 // @Success	200 {array} string "network rages"
+// @Header 200 {integer} X-Total-Count "total number of items"
 // @Failure	500 {string} string "error message"
 // @Router /blacklists/net-ranges [get]
 func GetNetRngBlacklistH(a lib.Api) (string, string, gin.HandlerFunc) {
@@ -116,7 +132,7 @@ func GetNetRngBlacklistH(a lib.Api) (string, string, gin.HandlerFunc) {
 			_ = gc.Error(err)
 			return
 		}
-		gc.JSON(http.StatusOK, values)
+		list_query.Respond(gc, values, blacklistSortFuncs, "value")
 	}
 }
 
@@ -176,3 +192,80 @@ func DeleteNetRngBlacklistValueH(a lib.Api) (string, string, gin.HandlerFunc) {
 		gc.Status(http.StatusOK)
 	}
 }
+
+// GetAppSocketBlacklistH godoc
+// @Summary List application sockets
+// @Description	List blacklisted application socket patterns. Entries from the configuration and the service's own socket are not included.
+// @Tags Blacklists
+// @Produce	json
+// @Param sort query string false "sort key" Enums(value)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param limit query int false "maximum number of items"
+// @Param offset query int false "number of items to skip"
+// @Success	200 {array} string "socket patterns"
+// @Header 200 {integer} X-Total-Count "total number of items"
+// @Failure	500 {string} string "error message"
+// @Router /blacklists/app-sockets [get]
+func GetAppSocketBlacklistH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, path.Join(lib_model.BlacklistsPath, lib_model.AppSocketsPath), func(gc *gin.Context) {
+		values, err := a.GetAppSocketBlacklist(gc.Request.Context())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		list_query.Respond(gc, values, blacklistSortFuncs, "value")
+	}
+}
+
+// PostAppSocketBlacklistValueH godoc
+// @Summary Add application socket
+// @Description	Add an application socket pattern to the list. Patterns are globs of socket paths (/run/docker*.sock), abstract socket names (@name), TCP addresses (127.0.0.1:*) or endpoint URIs. Registered applications matching the pattern are flagged as blacklisted.
+// @Tags Blacklists
+// @Accept plain
+// @Param value body string true "socket pattern"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /blacklists/app-sockets [post]
+func PostAppSocketBlacklistValueH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, path.Join(lib_model.BlacklistsPath, lib_model.AppSocketsPath), func(gc *gin.Context) {
+		defer gc.Request.Body.Close()
+		v, err := io.ReadAll(gc.Request.Body)
+		if err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err = a.AppSocketBlacklistAdd(gc.Request.Context(), string(v))
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// DeleteAppSocketBlacklistValueH godoc
+// @Summary Delete application socket
+// @Description	Remove an application socket pattern from the list.
+// @Tags Blacklists
+// @Param value query string true "socket pattern"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /blacklists/app-sockets [delete]
+func DeleteAppSocketBlacklistValueH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodDelete, path.Join(lib_model.BlacklistsPath, lib_model.AppSocketsPath), func(gc *gin.Context) {
+		query := deleteBlacklistValQuery{}
+		if err := gc.ShouldBindQuery(&query); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err := a.AppSocketBlacklistRemove(gc.Request.Context(), query.Value)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standard
+
+import (
+	"github.com/SENERGY-Platform/mgw-host-manager/lib"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path"
+)
+
+// PutHostResourceAnnotationsH godoc
+// @Summary Set annotations
+// @Description	Set a custom name and tags for a host resource.
+// @Tags Host Resources
+// @Accept json
+// @Param id path string true "resource id"
+// @Param annotations body lib_model.ResourceAnnotation true "name and tags"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/annotations [put]
+func PutHostResourceAnnotationsH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPut, path.Join(lib_model.HostResourcesPath, ":id", lib_model.AnnotationsPath), func(gc *gin.Context) {
+		var annotation lib_model.ResourceAnnotation
+		if err := gc.ShouldBindJSON(&annotation); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err := a.SetHostResourceAnnotation(gc.Request.Context(), gc.Param("id"), annotation)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// PatchHostResourceAnnotationsH godoc
+// @Summary Update annotations
+// @Description	Update the custom name or tags of a host resource. Omitted fields remain unchanged.
+// @Tags Host Resources
+// @Accept json
+// @Param id path string true "resource id"
+// @Param annotations body lib_model.ResourceAnnotationPatch true "name and/or tags"
+// @Success	200
+// @Failure	400 {string} string "error message"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/annotations [patch]
+func PatchHostResourceAnnotationsH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodPatch, path.Join(lib_model.HostResourcesPath, ":id", lib_model.AnnotationsPath), func(gc *gin.Context) {
+		var patch lib_model.ResourceAnnotationPatch
+		if err := gc.ShouldBindJSON(&patch); err != nil {
+			_ = gc.Error(lib_model.NewInvalidInputError(err))
+			return
+		}
+		err := a.UpdateHostResourceAnnotation(gc.Request.Context(), gc.Param("id"), patch)
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
+
+// DeleteHostResourceAnnotationsH godoc
+// @Summary Delete annotations
+// @Description	Remove the custom name and tags of a host resource.
+// @Tags Host Resources
+// @Param id path string true "resource id"
+// @Success	200
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /host-resources/{id}/annotations [delete]
+func DeleteHostResourceAnnotationsH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodDelete, path.Join(lib_model.HostResourcesPath, ":id", lib_model.AnnotationsPath), func(gc *gin.Context) {
+		err := a.RemoveHostResourceAnnotation(gc.Request.Context(), gc.Param("id"))
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.Status(http.StatusOK)
+	}
+}
@@ -34,9 +34,19 @@ var routes = gin_mw.Routes[lib.Api]{
 	GetNetRngBlacklistH,
 	PostNetRngBlacklistValueH,
 	DeleteNetRngBlacklistValueH,
+	GetAppSocketBlacklistH,
+	PostAppSocketBlacklistValueH,
+	DeleteAppSocketBlacklistValueH,
 	GetHostApplicationsH,
 	PostHostApplicationH,
+	PutHostApplicationH,
+	PatchHostApplicationH,
 	DeleteHostApplicationH,
+	GetHostApplicationHealthH,
+	GetStaticResourcesStatusH,
+	PutHostResourceAnnotationsH,
+	PatchHostResourceAnnotationsH,
+	DeleteHostResourceAnnotationsH,
 }
 
 // SetRoutes
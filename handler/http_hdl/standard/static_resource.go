@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standard
+
+import (
+	"github.com/SENERGY-Platform/mgw-host-manager/lib"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetStaticResourcesStatusH godoc
+// @Summary Get static resources status
+// @Description	Get the load status of static resource definition files.
+// @Tags Host Resources
+// @Produce	json
+// @Success	200 {object} lib_model.StaticResourcesStatus "status"
+// @Failure	404 {string} string "error message"
+// @Failure	500 {string} string "error message"
+// @Router /static-resources [get]
+func GetStaticResourcesStatusH(a lib.Api) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, lib_model.StaticResPath, func(gc *gin.Context) {
+		status, err := a.GetStaticResourcesStatus(gc.Request.Context())
+		if err != nil {
+			_ = gc.Error(err)
+			return
+		}
+		gc.JSON(http.StatusOK, status)
+	}
+}
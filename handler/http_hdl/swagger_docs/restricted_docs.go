@@ -71,9 +71,56 @@ const docTemplaterestricted = `{
                 }
             }
         },
+        "/host-info/network/neighbors": {
+            "get": {
+                "description": "List IPv4 and IPv6 neighbors from the ARP and neighbor discovery tables of the host. Neighbors on blacklisted interfaces or in blacklisted ranges are hidden.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Information"
+                ],
+                "summary": "Get network neighbors",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include neighbors with matching MAC address prefix (e.g.: 'b8:27:eb')",
+                        "name": "mac_prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "neighbors",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NetNeighbor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/host-resources": {
             "get": {
-                "description": "List host resources like application sockets or serial adapters.",
+                "description": "List host resources like application sockets or serial adapters. Resource types that fail or time out are omitted, with envelope=true an object containing the resources and an error for each of these types is returned.",
                 "produces": [
                     "application/json"
                 ],
@@ -81,13 +128,119 @@ const docTemplaterestricted = `{
                     "Host Resources"
                 ],
                 "summary": "List resources",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only resources with all tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include availability and permission status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "wrap resources and per type errors in an object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "type",
+                            "name",
+                            "path"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include the given fields",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "host resources",
+                        "description": "host resources and per type errors, without envelope only the array of host resources",
+                        "schema": {
+                            "$ref": "#/definitions/model.HostResourceList"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources/aliases": {
+            "get": {
+                "description": "List previous resource IDs and the stable resource IDs they resolve to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "List aliases",
+                "responses": {
+                    "200": {
+                        "description": "resource aliases",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.HostResource"
+                                "$ref": "#/definitions/model.ResourceAlias"
                             }
                         }
                     },
@@ -102,7 +255,7 @@ const docTemplaterestricted = `{
         },
         "/host-resources/{id}": {
             "get": {
-                "description": "Get a host resource.",
+                "description": "Get a host resource. Previous resource IDs are resolved via aliases.",
                 "produces": [
                     "application/json"
                 ],
@@ -117,6 +270,12 @@ const docTemplaterestricted = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include availability and permission status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -135,6 +294,180 @@ const docTemplaterestricted = `{
                 }
             }
         },
+        "/host-resources/{id}/reservation": {
+            "post": {
+                "description": "Reserve a host resource exclusively for an owner. Reserving an already held resource with the same owner renews the reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Reserve resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "owner and ttl",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reservation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Release the reservation of a host resource.",
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Release reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reservation owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Renew the reservation of a host resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Renew reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "owner and ttl",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reservation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/info": {
             "get": {
                 "description": "Get basic service and runtime information.",
@@ -163,7 +496,7 @@ const docTemplaterestricted = `{
         },
         "/mdns-discovery": {
             "get": {
-                "description": "Query MDNS devices on attached networks.",
+                "description": "Query MDNS devices on attached networks. Services browsed in the background are answered from the cache unless fresh is set.",
                 "produces": [
                     "application/json"
                 ],
@@ -190,6 +523,43 @@ const docTemplaterestricted = `{
                         "description": "set the maximum duration for the query (defaults to 1s)",
                         "name": "time_window",
                         "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)",
+                        "name": "interfaces",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ipv4",
+                            "ipv6",
+                            "both"
+                        ],
+                        "type": "string",
+                        "description": "IP traffic to use (defaults to ipv4)",
+                        "name": "ip_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include services with matching TXT records, 'key' requires the key and 'key=value' the value (e.g.: 'vendor=acme')",
+                        "name": "txt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "browse the network even if the service is cached",
+                        "name": "fresh",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -216,142 +586,1228 @@ const docTemplaterestricted = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "lib.MemStats": {
+        },
+        "/mdns-discovery/instance": {
+            "get": {
+                "description": "Resolve a single service instance on attached networks. Returns as soon as the instance responded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "MDNS instance lookup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "instance name",
+                        "name": "instance",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "MDNS service string (e.g.: '_http._tcp')",
+                        "name": "service",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "limit the query to a domain",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "set the maximum duration for the lookup (defaults to 1s)",
+                        "name": "time_window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)",
+                        "name": "interfaces",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ipv4",
+                            "ipv6",
+                            "both"
+                        ],
+                        "type": "string",
+                        "description": "IP traffic to use (defaults to ipv4)",
+                        "name": "ip_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "service",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mdns-discovery/stream": {
+            "get": {
+                "description": "Query MDNS devices on attached networks and receive each entry as soon as it is discovered. Entries are sent as newline delimited JSON until the time window ends or the client disconnects.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "MDNS query stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MDNS service string (e.g.: '_services._dns-sd._udp' for all available services)",
+                        "name": "service",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "limit the query to a domain",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "set the maximum duration for the query (defaults to 1s)",
+                        "name": "time_window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)",
+                        "name": "interfaces",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ipv4",
+                            "ipv6",
+                            "both"
+                        ],
+                        "type": "string",
+                        "description": "IP traffic to use (defaults to ipv4)",
+                        "name": "ip_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include services with matching TXT records, 'key' requires the key and 'key=value' the value (e.g.: 'vendor=acme')",
+                        "name": "txt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "stream of services",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mdns-discovery/types": {
+            "get": {
+                "description": "List the distinct service types announced on attached networks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "MDNS service types",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "limit the query to a domain",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "set the maximum duration for the query (defaults to 1s)",
+                        "name": "time_window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "browse the network even if the service types are cached",
+                        "name": "fresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "limit the query to the given network interfaces (defaults to all interfaces not hidden by blacklists)",
+                        "name": "interfaces",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ipv4",
+                            "ipv6",
+                            "both"
+                        ],
+                        "type": "string",
+                        "description": "IP traffic to use (defaults to ipv4)",
+                        "name": "ip_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "service types",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MDNSServiceType"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mdns-services": {
+            "get": {
+                "description": "List services announced via MDNS.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "List MDNS services",
+                "responses": {
+                    "200": {
+                        "description": "services",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MDNSService"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Announce a service via MDNS for an owner until the lease expires. Leases must be renewed by the owner before they expire and are kept across restarts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "Add MDNS service",
+                "parameters": [
+                    {
+                        "description": "service information, owner and lease ttl",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "service",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSService"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mdns-services/{id}": {
+            "get": {
+                "description": "Get a service announced via MDNS.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "Get MDNS service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "service",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSService"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop announcing a service and remove it. Only the owner can remove a service.",
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "Remove MDNS service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "service owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mdns-services/{id}/lease": {
+            "patch": {
+                "description": "Renew the lease of a service announced via MDNS. Only the owner can renew the lease.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDNS"
+                ],
+                "summary": "Renew MDNS service lease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "owner and lease ttl",
+                        "name": "lease",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSLeaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "service",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDNSService"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/net-scan-jobs": {
+            "get": {
+                "description": "List running and recently finished network scan jobs of an owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Network Scan"
+                ],
+                "summary": "List network scan jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "jobs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NetScanJob"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a rate limited TCP connect scan of a network. The network must belong to an interface not hidden by blacklists, hosts in blacklisted ranges are skipped. The number of running jobs is limited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Network Scan"
+                ],
+                "summary": "Start network scan",
+                "parameters": [
+                    {
+                        "description": "network, ports and owner",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NetScanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "job ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/net-scan-jobs/{id}": {
+            "get": {
+                "description": "Get the progress and the hosts with open ports found so far. Only the owner can get a job.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Network Scan"
+                ],
+                "summary": "Get network scan job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "job",
+                        "schema": {
+                            "$ref": "#/definitions/model.NetScanJob"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel a running job. Finished jobs are removed. Only the owner can cancel a job.",
+                "tags": [
+                    "Network Scan"
+                ],
+                "summary": "Cancel network scan job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ssdp-discovery": {
+            "get": {
+                "description": "Search for UPnP devices on allowed network interfaces via SSDP. Devices in blacklisted network ranges are omitted and descriptions are only fetched from the address of the responding device.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SSDP"
+                ],
+                "summary": "SSDP query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search target (defaults to 'ssdp:all', e.g.: 'upnp:rootdevice')",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "set the maximum duration for the query (defaults to 2s, maximum 10s)",
+                        "name": "time_window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "fetch and include device descriptions",
+                        "name": "description",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "list of devices",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SSDPDevice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "lib.MemStats": {
+            "type": "object",
+            "properties": {
+                "alloc": {
+                    "type": "integer"
+                },
+                "alloc_total": {
+                    "type": "integer"
+                },
+                "gc_cycles": {
+                    "type": "integer"
+                },
+                "sys_total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lib.SrvInfo": {
+            "type": "object",
+            "properties": {
+                "mem_stats": {
+                    "$ref": "#/definitions/lib.MemStats"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "up_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HostInfo": {
+            "type": "object",
+            "properties": {
+                "hardware": {},
+                "network": {
+                    "$ref": "#/definitions/model.HostNet"
+                },
+                "os": {}
+            }
+        },
+        "model.HostNet": {
+            "type": "object",
+            "properties": {
+                "hostname": {
+                    "type": "string"
+                },
+                "interfaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NetInterface"
+                    }
+                }
+            }
+        },
+        "model.HostResource": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "reservation": {
+                    "$ref": "#/definitions/model.ResourceReservation"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.HostResourceStatus"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/model.ResourceType"
+                }
+            }
+        },
+        "model.HostResourceList": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostResourceListErr"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostResource"
+                    }
+                }
+            }
+        },
+        "model.HostResourceListErr": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.ResourceType"
+                }
+            }
+        },
+        "model.HostResourceStatus": {
             "type": "object",
             "properties": {
-                "alloc": {
-                    "type": "integer"
+                "exists": {
+                    "type": "boolean"
                 },
-                "alloc_total": {
-                    "type": "integer"
+                "file_type": {
+                    "type": "string"
                 },
-                "gc_cycles": {
+                "gid": {
                     "type": "integer"
                 },
-                "sys_total": {
+                "listening": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "readable": {
+                    "type": "boolean"
+                },
+                "uid": {
                     "type": "integer"
+                },
+                "writable": {
+                    "type": "boolean"
                 }
             }
         },
-        "lib.SrvInfo": {
+        "model.MDNSEntry": {
             "type": "object",
             "properties": {
-                "mem_stats": {
-                    "$ref": "#/definitions/lib.MemStats"
+                "domain": {
+                    "type": "string"
+                },
+                "expiry": {
+                    "type": "string"
+                },
+                "fqdn": {
+                    "type": "string"
+                },
+                "hostname": {
+                    "description": "first label of the hostname",
+                    "type": "string"
+                },
+                "ipv4_addr": {
+                    "description": "first IPv4 address",
+                    "type": "string"
+                },
+                "ipv4_addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ipv6_addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "name": {
                     "type": "string"
                 },
-                "up_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "port": {
+                    "type": "integer"
                 },
-                "version": {
+                "subtypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "txt": {
+                    "description": "parsed TXT records with lower case keys, keys without value map to an empty string",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "txt_records": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "model.HostInfo": {
+        "model.MDNSLeaseRequest": {
             "type": "object",
             "properties": {
-                "hardware": {},
-                "network": {
-                    "$ref": "#/definitions/model.HostNet"
+                "owner": {
+                    "type": "string"
                 },
-                "os": {}
+                "ttl": {
+                    "description": "seconds",
+                    "type": "integer"
+                }
             }
         },
-        "model.HostNet": {
+        "model.MDNSService": {
             "type": "object",
             "properties": {
-                "hostname": {
+                "created": {
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "defaults to 'local.'",
+                    "type": "string"
+                },
+                "expires": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
                 },
                 "interfaces": {
+                    "description": "announce on all allowed interfaces if empty",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.NetInterface"
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "instance name",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "txt_records": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "type": {
+                    "description": "service type (e.g.: '_http._tcp')",
+                    "type": "string"
                 }
             }
         },
-        "model.HostResource": {
+        "model.MDNSServiceRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "domain": {
+                    "description": "defaults to 'local.'",
                     "type": "string"
                 },
+                "interfaces": {
+                    "description": "announce on all allowed interfaces if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
+                    "description": "instance name",
                     "type": "string"
                 },
-                "path": {
+                "owner": {
                     "type": "string"
                 },
-                "tags": {
+                "port": {
+                    "type": "integer"
+                },
+                "ttl": {
+                    "description": "seconds",
+                    "type": "integer"
+                },
+                "txt_records": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
                 "type": {
-                    "$ref": "#/definitions/model.ResourceType"
+                    "description": "service type (e.g.: '_http._tcp')",
+                    "type": "string"
                 }
             }
         },
-        "model.MDNSEntry": {
+        "model.MDNSServiceType": {
             "type": "object",
             "properties": {
                 "domain": {
                     "type": "string"
                 },
-                "expiry": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NetInterface": {
+            "type": "object",
+            "properties": {
+                "ipv4_addr": {
                     "type": "string"
                 },
-                "hostname": {
+                "ipv4_mask": {
                     "type": "string"
                 },
-                "ipv4_addr": {
+                "ipv4_net": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
+                }
+            }
+        },
+        "model.NetNeighbor": {
+            "type": "object",
+            "properties": {
+                "interface": {
+                    "type": "string"
                 },
-                "port": {
+                "ip_addr": {
+                    "type": "string"
+                },
+                "mac_addr": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "neighbor state (e.g.: reachable, stale, permanent), ARP entries only distinguish between complete and permanent",
+                    "type": "string"
+                },
+                "vendor": {
+                    "description": "vendor of the MAC address, empty if the OUI is unknown or the address is locally administered",
+                    "type": "string"
+                }
+            }
+        },
+        "model.NetScanHost": {
+            "type": "object",
+            "properties": {
+                "ip_addr": {
+                    "type": "string"
+                },
+                "open_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.NetScanJob": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "description": "IPv4 network that must belong to a host interface (e.g.: '192.168.1.0/24')",
+                    "type": "string"
+                },
+                "completed": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "finished": {
+                    "description": "number of finished connection attempts",
                     "type": "integer"
                 },
-                "subtypes": {
+                "hosts": {
+                    "description": "hosts with at least one open port",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.NetScanHost"
                     }
                 },
-                "txt_records": {
+                "id": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "ports": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "type": {
+                "state": {
+                    "$ref": "#/definitions/model.NetScanJobState"
+                },
+                "total": {
+                    "description": "number of connection attempts",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NetScanJobState": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "canceled"
+            ],
+            "x-enum-varnames": [
+                "NetScanJobRunning",
+                "NetScanJobCompleted",
+                "NetScanJobCanceled"
+            ]
+        },
+        "model.NetScanRequest": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "description": "IPv4 network that must belong to a host interface (e.g.: '192.168.1.0/24')",
+                    "type": "string"
+                },
+                "owner": {
                     "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "model.NetInterface": {
+        "model.ResourceAlias": {
             "type": "object",
             "properties": {
-                "ipv4_addr": {
+                "alias": {
                     "type": "string"
                 },
-                "ipv4_mask": {
+                "resource_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceReservation": {
+            "type": "object",
+            "properties": {
+                "created": {
                     "type": "string"
                 },
-                "ipv4_net": {
+                "expires": {
                     "type": "string"
                 },
-                "name": {
+                "owner": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceReservationRequest": {
+            "type": "object",
+            "properties": {
+                "owner": {
                     "type": "string"
+                },
+                "ttl": {
+                    "description": "seconds",
+                    "type": "integer"
                 }
             }
         },
@@ -359,13 +1815,80 @@ const docTemplaterestricted = `{
             "type": "string",
             "enum": [
                 "serial",
-                "app"
+                "app",
+                "gpio",
+                "i2c",
+                "spi",
+                "video",
+                "audio",
+                "can",
+                "static"
             ],
             "x-enum-varnames": [
                 "SerialDevice",
-                "Application"
+                "Application",
+                "GPIOChip",
+                "I2CBus",
+                "SPIDevice",
+                "VideoDevice",
+                "SoundCard",
+                "CANInterface",
+                "Static"
             ]
         },
+        "model.SSDPDevice": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "description": "address of the responding host",
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/model.SSDPDeviceDescription"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "server": {
+                    "type": "string"
+                },
+                "st": {
+                    "type": "string"
+                },
+                "usn": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SSDPDeviceDescription": {
+            "type": "object",
+            "properties": {
+                "device_type": {
+                    "type": "string"
+                },
+                "friendly_name": {
+                    "type": "string"
+                },
+                "manufacturer": {
+                    "type": "string"
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "model_number": {
+                    "type": "string"
+                },
+                "presentation_url": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "udn": {
+                    "type": "string"
+                }
+            }
+        },
         "time.Duration": {
             "type": "integer",
             "enum": [
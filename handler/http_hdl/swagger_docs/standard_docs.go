@@ -29,6 +29,51 @@ const docTemplatestandard = `{
                     "Host Applications"
                 ],
                 "summary": "List applications",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "socket"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include the given fields",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "host applications",
@@ -37,6 +82,12 @@ const docTemplatestandard = `{
                             "items": {
                                 "$ref": "#/definitions/model.HostApplication"
                             }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
                         }
                     },
                     "500": {
@@ -93,25 +144,43 @@ const docTemplatestandard = `{
             }
         },
         "/applications/{id}": {
-            "delete": {
-                "description": "Remove a host application.",
+            "put": {
+                "description": "Replace the information of a host application. The application ID is kept.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "Host Applications"
                 ],
-                "summary": "Delete application",
+                "summary": "Update application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "resource id",
+                        "description": "application id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "application information",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HostApplicationBase"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "404": {
                         "description": "error message",
                         "schema": {
@@ -125,26 +194,30 @@ const docTemplatestandard = `{
                         }
                     }
                 }
-            }
-        },
-        "/blacklists/net-interfaces": {
-            "get": {
-                "description": "List blacklisted host network interfaces.",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove a host application.",
                 "tags": [
-                    "Blacklists"
+                    "Host Applications"
+                ],
+                "summary": "Delete application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List network interfaces",
                 "responses": {
                     "200": {
-                        "description": "network interfaces",
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "error message",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -155,23 +228,30 @@ const docTemplatestandard = `{
                     }
                 }
             },
-            "post": {
-                "description": "Add a host network interface to the list.",
+            "patch": {
+                "description": "Change individual fields of a host application, omitted fields are kept.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Blacklists"
+                    "Host Applications"
                 ],
-                "summary": "Add network interface",
+                "summary": "Patch application",
                 "parameters": [
                     {
-                        "description": "interface name",
-                        "name": "value",
+                        "type": "string",
+                        "description": "application id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "patch",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.HostApplicationPatch"
                         }
                     }
                 ],
@@ -185,6 +265,12 @@ const docTemplatestandard = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "error message",
                         "schema": {
@@ -192,30 +278,32 @@ const docTemplatestandard = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove a host network interface from the list.",
+            }
+        },
+        "/applications/{id}/health": {
+            "get": {
+                "description": "Get the result of the last health check of a host application.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Blacklists"
+                    "Host Applications"
                 ],
-                "summary": "Delete network interface",
+                "summary": "Get application health",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "interface name",
-                        "name": "value",
-                        "in": "query",
+                        "description": "application id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "error message",
+                        "description": "health check result",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.HostAppHealth"
                         }
                     },
                     "404": {
@@ -233,24 +321,63 @@ const docTemplatestandard = `{
                 }
             }
         },
-        "/blacklists/net-ranges": {
+        "/blacklists/app-sockets": {
             "get": {
-                "description": "List blacklisted network ranges.",
+                "description": "List blacklisted application socket patterns. Entries from the configuration and the service's own socket are not included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Blacklists"
                 ],
-                "summary": "List network ranges",
+                "summary": "List application sockets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "value"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "network rages",
+                        "description": "socket patterns",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "type": "string"
                             }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
                         }
                     },
                     "500": {
@@ -262,17 +389,17 @@ const docTemplatestandard = `{
                 }
             },
             "post": {
-                "description": "Add a network range to the list.",
+                "description": "Add an application socket pattern to the list. Patterns are globs of socket paths (/run/docker*.sock), abstract socket names (@name), TCP addresses (127.0.0.1:*) or endpoint URIs. Registered applications matching the pattern are flagged as blacklisted.",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
                     "Blacklists"
                 ],
-                "summary": "Add network range",
+                "summary": "Add application socket",
                 "parameters": [
                     {
-                        "description": "network range in CIDR notation",
+                        "description": "socket pattern",
                         "name": "value",
                         "in": "body",
                         "required": true,
@@ -300,15 +427,15 @@ const docTemplatestandard = `{
                 }
             },
             "delete": {
-                "description": "Remove a network range from the list.",
+                "description": "Remove an application socket pattern from the list.",
                 "tags": [
                     "Blacklists"
                 ],
-                "summary": "Delete network range",
+                "summary": "Delete application socket",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "network range",
+                        "description": "socket pattern",
                         "name": "value",
                         "in": "query",
                         "required": true
@@ -339,21 +466,63 @@ const docTemplatestandard = `{
                 }
             }
         },
-        "/host-info": {
+        "/blacklists/net-interfaces": {
             "get": {
-                "description": "Get host information.",
+                "description": "List blacklisted host network interfaces.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Host Information"
+                    "Blacklists"
+                ],
+                "summary": "List network interfaces",
+                "parameters": [
+                    {
+                        "enum": [
+                            "value"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all",
                 "responses": {
                     "200": {
-                        "description": "host info",
+                        "description": "network interfaces",
                         "schema": {
-                            "$ref": "#/definitions/model.HostInfo"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
                         }
                     },
                     "500": {
@@ -363,52 +532,35 @@ const docTemplatestandard = `{
                         }
                     }
                 }
-            }
-        },
-        "/host-info/network": {
-            "get": {
-                "description": "Get host network information.",
-                "produces": [
-                    "application/json"
+            },
+            "post": {
+                "description": "Add a host network interface to the list.",
+                "consumes": [
+                    "text/plain"
                 ],
                 "tags": [
-                    "Host Information"
+                    "Blacklists"
                 ],
-                "summary": "Get network",
-                "responses": {
-                    "200": {
-                        "description": "host network info",
-                        "schema": {
-                            "$ref": "#/definitions/model.HostNet"
-                        }
-                    },
-                    "500": {
-                        "description": "error message",
+                "summary": "Add network interface",
+                "parameters": [
+                    {
+                        "description": "interface name",
+                        "name": "value",
+                        "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/host-resources": {
-            "get": {
-                "description": "List host resources like application sockets or serial adapters.",
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "Host Resources"
-                ],
-                "summary": "List resources",
                 "responses": {
                     "200": {
-                        "description": "host resources",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.HostResource"
-                            }
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -418,32 +570,36 @@ const docTemplatestandard = `{
                         }
                     }
                 }
-            }
-        },
-        "/host-resources/{id}": {
-            "get": {
-                "description": "Get a host resource.",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove a host network interface from the list.",
                 "tags": [
-                    "Host Resources"
+                    "Blacklists"
                 ],
-                "summary": "Get resource",
+                "summary": "Delete network interface",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "resource id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "interface name",
+                        "name": "value",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "host resources",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
                         "schema": {
-                            "$ref": "#/definitions/model.HostResource"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -455,14 +611,745 @@ const docTemplatestandard = `{
                 }
             }
         },
-        "/info": {
+        "/blacklists/net-ranges": {
             "get": {
-                "description": "Get basic service and runtime information.",
+                "description": "List blacklisted network ranges.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Service Information"
+                    "Blacklists"
+                ],
+                "summary": "List network ranges",
+                "parameters": [
+                    {
+                        "enum": [
+                            "value"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "network rages",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a network range to the list.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Blacklists"
+                ],
+                "summary": "Add network range",
+                "parameters": [
+                    {
+                        "description": "network range in CIDR notation",
+                        "name": "value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a network range from the list.",
+                "tags": [
+                    "Blacklists"
+                ],
+                "summary": "Delete network range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network range",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-info": {
+            "get": {
+                "description": "Get host information.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Information"
+                ],
+                "summary": "Get all",
+                "responses": {
+                    "200": {
+                        "description": "host info",
+                        "schema": {
+                            "$ref": "#/definitions/model.HostInfo"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-info/network": {
+            "get": {
+                "description": "Get host network information.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Information"
+                ],
+                "summary": "Get network",
+                "responses": {
+                    "200": {
+                        "description": "host network info",
+                        "schema": {
+                            "$ref": "#/definitions/model.HostNet"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-info/network/neighbors": {
+            "get": {
+                "description": "List IPv4 and IPv6 neighbors from the ARP and neighbor discovery tables of the host. Neighbors on blacklisted interfaces or in blacklisted ranges are hidden.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Information"
+                ],
+                "summary": "Get network neighbors",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include neighbors with matching MAC address prefix (e.g.: 'b8:27:eb')",
+                        "name": "mac_prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "neighbors",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NetNeighbor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources": {
+            "get": {
+                "description": "List host resources like application sockets or serial adapters. Resource types that fail or time out are omitted, with envelope=true an object containing the resources and an error for each of these types is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "List resources",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only resources with all tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include availability and permission status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "wrap resources and per type errors in an object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "type",
+                            "name",
+                            "path"
+                        ],
+                        "type": "string",
+                        "description": "sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only include the given fields",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "host resources and per type errors, without envelope only the array of host resources",
+                        "schema": {
+                            "$ref": "#/definitions/model.HostResourceList"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "total number of items"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources/aliases": {
+            "get": {
+                "description": "List previous resource IDs and the stable resource IDs they resolve to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "List aliases",
+                "responses": {
+                    "200": {
+                        "description": "resource aliases",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ResourceAlias"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources/{id}": {
+            "get": {
+                "description": "Get a host resource. Previous resource IDs are resolved via aliases.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Get resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include availability and permission status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "host resources",
+                        "schema": {
+                            "$ref": "#/definitions/model.HostResource"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources/{id}/annotations": {
+            "put": {
+                "description": "Set a custom name and tags for a host resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Set annotations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "name and tags",
+                        "name": "annotations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceAnnotation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the custom name and tags of a host resource.",
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Delete annotations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the custom name or tags of a host resource. Omitted fields remain unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Update annotations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "name and/or tags",
+                        "name": "annotations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceAnnotationPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/host-resources/{id}/reservation": {
+            "post": {
+                "description": "Reserve a host resource exclusively for an owner. Reserving an already held resource with the same owner renews the reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Reserve resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "owner and ttl",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reservation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Release the reservation of a host resource.",
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Release reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reservation owner",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Renew the reservation of a host resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Renew reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resource id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "owner and ttl",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reservation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResourceReservation"
+                        }
+                    },
+                    "400": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/info": {
+            "get": {
+                "description": "Get basic service and runtime information.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Information"
                 ],
                 "summary": "Get stats",
                 "responses": {
@@ -480,6 +1367,38 @@ const docTemplatestandard = `{
                     }
                 }
             }
+        },
+        "/static-resources": {
+            "get": {
+                "description": "Get the load status of static resource definition files.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Host Resources"
+                ],
+                "summary": "Get static resources status",
+                "responses": {
+                    "200": {
+                        "description": "status",
+                        "schema": {
+                            "$ref": "#/definitions/model.StaticResourcesStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -517,26 +1436,197 @@ const docTemplatestandard = `{
                 }
             }
         },
+        "model.HostAppEndpointType": {
+            "type": "string",
+            "enum": [
+                "unix",
+                "unix-abstract",
+                "tcp"
+            ],
+            "x-enum-varnames": [
+                "HostAppEndpointUnix",
+                "HostAppEndpointUnixAbstract",
+                "HostAppEndpointTCP"
+            ]
+        },
+        "model.HostAppHealth": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "latency": {
+                    "description": "seconds",
+                    "type": "number"
+                }
+            }
+        },
+        "model.HostAppHealthCheck": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "seconds",
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "description": "seconds",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HostAppProtocol": {
+            "type": "string",
+            "enum": [
+                "http",
+                "grpc",
+                "raw"
+            ],
+            "x-enum-varnames": [
+                "HostAppProtocolHTTP",
+                "HostAppProtocolGRPC",
+                "HostAppProtocolRaw"
+            ]
+        },
+        "model.HostAppSource": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "discovered"
+            ],
+            "x-enum-varnames": [
+                "HostAppSourceManual",
+                "HostAppSourceDiscovered"
+            ]
+        },
         "model.HostApplication": {
             "type": "object",
             "properties": {
+                "base_path": {
+                    "description": "only for http",
+                    "type": "string"
+                },
+                "blacklisted": {
+                    "description": "endpoint matches a blacklist entry added after registration",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endpoint_type": {
+                    "$ref": "#/definitions/model.HostAppEndpointType"
+                },
+                "health": {
+                    "$ref": "#/definitions/model.HostAppHealth"
+                },
+                "health_check": {
+                    "$ref": "#/definitions/model.HostAppHealthCheck"
+                },
                 "id": {
                     "type": "string"
                 },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
+                "owner": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "$ref": "#/definitions/model.HostAppProtocol"
+                },
                 "socket": {
+                    "description": "socket path, abstract socket name starting with '@' or TCP address depending on the endpoint type",
                     "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/model.HostAppSource"
                 }
             }
         },
         "model.HostApplicationBase": {
             "type": "object",
             "properties": {
+                "base_path": {
+                    "description": "only for http",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endpoint_type": {
+                    "$ref": "#/definitions/model.HostAppEndpointType"
+                },
+                "health_check": {
+                    "$ref": "#/definitions/model.HostAppHealthCheck"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "$ref": "#/definitions/model.HostAppProtocol"
+                },
+                "socket": {
+                    "description": "socket path, abstract socket name starting with '@' or TCP address depending on the endpoint type",
+                    "type": "string"
+                }
+            }
+        },
+        "model.HostApplicationPatch": {
+            "type": "object",
+            "properties": {
+                "base_path": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endpoint_type": {
+                    "$ref": "#/definitions/model.HostAppEndpointType"
+                },
+                "health_check": {
+                    "$ref": "#/definitions/model.HostAppHealthCheck"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
+                "owner": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "$ref": "#/definitions/model.HostAppProtocol"
+                },
                 "socket": {
                     "type": "string"
                 }
@@ -569,6 +1659,12 @@ const docTemplatestandard = `{
         "model.HostResource": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
@@ -578,6 +1674,12 @@ const docTemplatestandard = `{
                 "path": {
                     "type": "string"
                 },
+                "reservation": {
+                    "$ref": "#/definitions/model.ResourceReservation"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.HostResourceStatus"
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -589,6 +1691,63 @@ const docTemplatestandard = `{
                 }
             }
         },
+        "model.HostResourceList": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostResourceListErr"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostResource"
+                    }
+                }
+            }
+        },
+        "model.HostResourceListErr": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.ResourceType"
+                }
+            }
+        },
+        "model.HostResourceStatus": {
+            "type": "object",
+            "properties": {
+                "exists": {
+                    "type": "boolean"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "gid": {
+                    "type": "integer"
+                },
+                "listening": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "readable": {
+                    "type": "boolean"
+                },
+                "uid": {
+                    "type": "integer"
+                },
+                "writable": {
+                    "type": "boolean"
+                }
+            }
+        },
         "model.NetInterface": {
             "type": "object",
             "properties": {
@@ -606,17 +1765,155 @@ const docTemplatestandard = `{
                 }
             }
         },
+        "model.NetNeighbor": {
+            "type": "object",
+            "properties": {
+                "interface": {
+                    "type": "string"
+                },
+                "ip_addr": {
+                    "type": "string"
+                },
+                "mac_addr": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "neighbor state (e.g.: reachable, stale, permanent), ARP entries only distinguish between complete and permanent",
+                    "type": "string"
+                },
+                "vendor": {
+                    "description": "vendor of the MAC address, empty if the OUI is unknown or the address is locally administered",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceAlias": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceAnnotation": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ResourceAnnotationPatch": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ResourceReservation": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "expires": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceReservationRequest": {
+            "type": "object",
+            "properties": {
+                "owner": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "seconds",
+                    "type": "integer"
+                }
+            }
+        },
         "model.ResourceType": {
             "type": "string",
             "enum": [
                 "serial",
-                "app"
+                "app",
+                "gpio",
+                "i2c",
+                "spi",
+                "video",
+                "audio",
+                "can",
+                "static"
             ],
             "x-enum-varnames": [
                 "SerialDevice",
-                "Application"
+                "Application",
+                "GPIOChip",
+                "I2CBus",
+                "SPIDevice",
+                "VideoDevice",
+                "SoundCard",
+                "CANInterface",
+                "Static"
             ]
         },
+        "model.StaticResourceFile": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resources": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StaticResourcesStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StaticResourceFile"
+                    }
+                },
+                "loaded": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
         "time.Duration": {
             "type": "integer",
             "enum": [
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
+	"os"
+	"path"
+	"sync"
+	"time"
+)
+
+// seenInterval is the interval in which the time resources were last seen is persisted.
+const seenInterval = time.Hour
+
+type store struct {
+	Identities map[model.ResourceType]map[string]string `json:"identities"` // type:{hardware identity:stable ID}
+	Aliases    map[string]string                        `json:"aliases"`    // alias ID:stable ID
+	LastSeen   map[string]time.Time                     `json:"last_seen"`  // stable ID:time the resource was last seen
+}
+
+type Handler struct {
+	sto    store
+	ids    map[string]struct{}
+	path   string
+	maxAge time.Duration
+	mu     sync.RWMutex
+}
+
+// New creates a handler that stores identities at path p. Identities of resources not seen for longer than maxAge are
+// removed together with their aliases unless maxAge is 0.
+func New(p string, maxAge time.Duration) (*Handler, error) {
+	if !path.IsAbs(p) {
+		return nil, fmt.Errorf("path '%s' not absolute", p)
+	}
+	return &Handler{
+		path:   p,
+		sto:    newStore(),
+		ids:    make(map[string]struct{}),
+		maxAge: maxAge,
+	}, nil
+}
+
+func (h *Handler) Init() error {
+	sto := newStore()
+	if err := json_sto_file.Read(h.path, &sto); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if sto.Identities == nil {
+		sto.Identities = make(map[model.ResourceType]map[string]string)
+	}
+	if sto.Aliases == nil {
+		sto.Aliases = make(map[string]string)
+	}
+	if sto.LastSeen == nil {
+		sto.LastSeen = make(map[string]time.Time)
+	}
+	// identities stored before the time was recorded count as seen now, so they are not removed right away
+	timestamp := time.Now().UTC().Truncate(time.Second)
+	for _, identities := range sto.Identities {
+		for _, id := range identities {
+			if _, ok := sto.LastSeen[id]; !ok {
+				sto.LastSeen[id] = timestamp
+			}
+		}
+	}
+	h.sto = sto
+	h.ids = genIDSet(sto)
+	return nil
+}
+
+// StableIDs maps the current IDs of resources to stable IDs via the provided hardware identities. Unknown identities
+// are assigned the current ID if it is not already in use, otherwise a new ID is derived from the identity.
+// Current IDs that differ from the stable ID are recorded as aliases. Identities of the type not provided for longer
+// than the maximum age are removed.
+func (h *Handler) StableIDs(_ context.Context, rType model.ResourceType, identities map[string]string) (map[string]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now().UTC().Truncate(time.Second)
+	stableIDs := make(map[string]string)
+	newIdentities := make(map[string]string)
+	newAliases := make(map[string]string)
+	newIDs := make(map[string]struct{})
+	isUsed := func(id string) bool {
+		_, ok := h.ids[id]
+		if !ok {
+			_, ok = newIDs[id]
+		}
+		return ok
+	}
+	for rID, identity := range identities {
+		stableID, ok := h.sto.Identities[rType][identity]
+		if !ok {
+			if stableID, ok = newIdentities[identity]; !ok {
+				stableID = rID
+				if isUsed(stableID) {
+					stableID = rType + ":" + util.GenHash(rType, identity)
+				}
+				newIdentities[identity] = stableID
+				newIDs[stableID] = struct{}{}
+			}
+		}
+		if rID != stableID && !isUsed(rID) {
+			newAliases[rID] = stableID
+			newIDs[rID] = struct{}{}
+		}
+		stableIDs[rID] = stableID
+	}
+	var seen []string
+	for _, stableID := range stableIDs {
+		if timestamp.Sub(h.sto.LastSeen[stableID]) >= seenInterval {
+			seen = append(seen, stableID)
+		}
+	}
+	expired := h.getExpired(rType, stableIDs, timestamp)
+	if len(newIdentities) == 0 && len(newAliases) == 0 && len(seen) == 0 && len(expired) == 0 {
+		return stableIDs, nil
+	}
+	newSto := copyStore(h.sto)
+	for _, identity := range expired {
+		removeIdentity(newSto, rType, identity)
+	}
+	if _, ok := newSto.Identities[rType]; !ok {
+		newSto.Identities[rType] = make(map[string]string)
+	}
+	for identity, id := range newIdentities {
+		newSto.Identities[rType][identity] = id
+	}
+	for alias, id := range newAliases {
+		newSto.Aliases[alias] = id
+	}
+	for _, id := range seen {
+		newSto.LastSeen[id] = timestamp
+	}
+	if err := json_sto_file.Write(newSto, h.path, true); err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	h.sto = newSto
+	h.ids = genIDSet(newSto)
+	return stableIDs, nil
+}
+
+// Resolve returns the stable ID of an alias or the provided ID if no alias exists.
+func (h *Handler) Resolve(_ context.Context, rID string) (string, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if stableID, ok := h.sto.Aliases[rID]; ok {
+		return stableID, nil
+	}
+	return rID, nil
+}
+
+func (h *Handler) ListAliases(_ context.Context) ([]model.ResourceAlias, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var aliases []model.ResourceAlias
+	for alias, rID := range h.sto.Aliases {
+		aliases = append(aliases, model.ResourceAlias{
+			Alias:      alias,
+			ResourceID: rID,
+		})
+	}
+	return aliases, nil
+}
+
+// getExpired returns the identities of the type whose stable IDs are not in use and were last seen more than the
+// maximum age ago.
+func (h *Handler) getExpired(rType model.ResourceType, stableIDs map[string]string, timestamp time.Time) []string {
+	if h.maxAge <= 0 {
+		return nil
+	}
+	inUse := make(map[string]struct{})
+	for _, id := range stableIDs {
+		inUse[id] = struct{}{}
+	}
+	var expired []string
+	for identity, id := range h.sto.Identities[rType] {
+		if _, ok := inUse[id]; ok {
+			continue
+		}
+		if timestamp.Sub(h.sto.LastSeen[id]) > h.maxAge {
+			expired = append(expired, identity)
+		}
+	}
+	return expired
+}
+
+func removeIdentity(sto store, rType model.ResourceType, identity string) {
+	id := sto.Identities[rType][identity]
+	delete(sto.Identities[rType], identity)
+	delete(sto.LastSeen, id)
+	for alias, stableID := range sto.Aliases {
+		if stableID == id {
+			delete(sto.Aliases, alias)
+		}
+	}
+}
+
+func newStore() store {
+	return store{
+		Identities: make(map[model.ResourceType]map[string]string),
+		Aliases:    make(map[string]string),
+		LastSeen:   make(map[string]time.Time),
+	}
+}
+
+func copyStore(sto store) store {
+	newSto := newStore()
+	for t, identities := range sto.Identities {
+		m := make(map[string]string)
+		for identity, id := range identities {
+			m[identity] = id
+		}
+		newSto.Identities[t] = m
+	}
+	for alias, id := range sto.Aliases {
+		newSto.Aliases[alias] = id
+	}
+	for id, t := range sto.LastSeen {
+		newSto.LastSeen[id] = t
+	}
+	return newSto
+}
+
+func genIDSet(sto store) map[string]struct{} {
+	ids := make(map[string]struct{})
+	for _, identities := range sto.Identities {
+		for _, id := range identities {
+			ids[id] = struct{}{}
+		}
+	}
+	for alias := range sto.Aliases {
+		ids[alias] = struct{}{}
+	}
+	return ids
+}
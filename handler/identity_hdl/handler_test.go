@@ -0,0 +1,176 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHandler_Init(t *testing.T) {
+	tmpFilePath := path.Join(t.TempDir(), "test.json")
+	t.Run("file does not exist", func(t *testing.T) {
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("file exists", func(t *testing.T) {
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if _, err = h.StableIDs(context.Background(), "serial", map[string]string{"serial:a": "usb:1"}); err != nil {
+			t.Fatal(err)
+		}
+		h2, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h2.Init(); err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(h.sto, h2.sto) {
+			t.Errorf("got %+v, expected %+v", h2.sto, h.sto)
+		}
+		if !reflect.DeepEqual(h.ids, h2.ids) {
+			t.Errorf("got %+v, expected %+v", h2.ids, h.ids)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		f, err := os.Create(tmpFilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestHandler_StableIDs(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 0)
+	if err != nil {
+		t.Error(err)
+	}
+	ctx := context.Background()
+	t.Run("new identity keeps current id", func(t *testing.T) {
+		ids, err := h.StableIDs(ctx, "serial", map[string]string{"serial:a": "usb:1"})
+		if err != nil {
+			t.Error(err)
+		}
+		if a := map[string]string{"serial:a": "serial:a"}; !reflect.DeepEqual(a, ids) {
+			t.Errorf("got %+v, expected %+v", ids, a)
+		}
+	})
+	t.Run("moved device gets alias", func(t *testing.T) {
+		ids, err := h.StableIDs(ctx, "serial", map[string]string{"serial:b": "usb:1"})
+		if err != nil {
+			t.Error(err)
+		}
+		if a := map[string]string{"serial:b": "serial:a"}; !reflect.DeepEqual(a, ids) {
+			t.Errorf("got %+v, expected %+v", ids, a)
+		}
+		id, err := h.Resolve(ctx, "serial:b")
+		if err != nil {
+			t.Error(err)
+		}
+		if id != "serial:a" {
+			t.Errorf("got %s, expected %s", id, "serial:a")
+		}
+		aliases, err := h.ListAliases(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		if a := []model.ResourceAlias{{Alias: "serial:b", ResourceID: "serial:a"}}; !reflect.DeepEqual(a, aliases) {
+			t.Errorf("got %+v, expected %+v", aliases, a)
+		}
+	})
+	t.Run("used id", func(t *testing.T) {
+		ids, err := h.StableIDs(ctx, "serial", map[string]string{"serial:a": "usb:2"})
+		if err != nil {
+			t.Error(err)
+		}
+		if a := map[string]string{"serial:a": "serial:" + util.GenHash("serial", "usb:2")}; !reflect.DeepEqual(a, ids) {
+			t.Errorf("got %+v, expected %+v", ids, a)
+		}
+	})
+	t.Run("unknown alias", func(t *testing.T) {
+		id, err := h.Resolve(ctx, "serial:c")
+		if err != nil {
+			t.Error(err)
+		}
+		if id != "serial:c" {
+			t.Errorf("got %s, expected %s", id, "serial:c")
+		}
+	})
+}
+
+func TestHandler_StableIDsExpired(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err = h.StableIDs(ctx, "serial", map[string]string{"serial:a": "usb:1", "serial:b": "usb:2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = h.StableIDs(ctx, "serial", map[string]string{"serial:c": "usb:1"}); err != nil {
+		t.Fatal(err)
+	}
+	h.sto.LastSeen["serial:a"] = time.Now().Add(-48 * time.Hour)
+	h.sto.LastSeen["serial:b"] = time.Now().Add(-48 * time.Hour)
+	if _, err = h.StableIDs(ctx, "serial", map[string]string{"serial:a": "usb:1"}); err != nil {
+		t.Fatal(err)
+	}
+	if a := map[string]string{"usb:1": "serial:a"}; !reflect.DeepEqual(a, h.sto.Identities["serial"]) {
+		t.Errorf("got %+v, expected %+v", h.sto.Identities["serial"], a)
+	}
+	if _, ok := h.sto.LastSeen["serial:b"]; ok {
+		t.Error("expected last seen time to be removed")
+	}
+	if time.Since(h.sto.LastSeen["serial:a"]) > time.Minute {
+		t.Error("expected last seen time to be updated")
+	}
+	if len(h.sto.Aliases) != 1 {
+		t.Errorf("expected alias of seen resource to be kept, got %+v", h.sto.Aliases)
+	}
+	if _, err = h.StableIDs(ctx, "serial", nil); err != nil {
+		t.Fatal(err)
+	}
+	h.sto.LastSeen["serial:a"] = time.Now().Add(-48 * time.Hour)
+	if _, err = h.StableIDs(ctx, "serial", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.sto.Identities["serial"]) != 0 || len(h.sto.Aliases) != 0 {
+		t.Errorf("expected identities and aliases to be removed, got %+v", h.sto)
+	}
+}
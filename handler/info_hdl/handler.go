@@ -25,9 +25,11 @@ type Handler struct {
 	netInterfaceBlacklistHdl BlacklistHandler
 	netRangeBlacklist        []*net.IPNet
 	netRangeBlacklistHdl     BlacklistHandler
+	procfsPath               string
+	vendors                  map[string]string
 }
 
-func New(netInterfaceBlacklist, netRangeBlacklist []string, netInterfaceBlacklistHdl, netRangeBlacklistHdl BlacklistHandler) (*Handler, error) {
+func New(netInterfaceBlacklist, netRangeBlacklist []string, netInterfaceBlacklistHdl, netRangeBlacklistHdl BlacklistHandler, procfsPath string) (*Handler, error) {
 	ipNets, err := genIPNets(netRangeBlacklist)
 	if err != nil {
 		return nil, err
@@ -37,5 +39,6 @@ func New(netInterfaceBlacklist, netRangeBlacklist []string, netInterfaceBlacklis
 		netInterfaceBlacklistHdl: netInterfaceBlacklistHdl,
 		netRangeBlacklist:        ipNets,
 		netRangeBlacklistHdl:     netRangeBlacklistHdl,
+		procfsPath:               procfsPath,
 	}, nil
 }
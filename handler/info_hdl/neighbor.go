@@ -0,0 +1,261 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package info_hdl
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"io"
+	"net"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// IPv4 neighbors are read from the ARP table in procfs, the kernel provides no equivalent for IPv6 and the neighbor
+// discovery table must be read via netlink.
+
+const (
+	arpTablePath = "net/arp"
+	atfCom       = 0x02
+	atfPerm      = 0x04
+	ndaDst       = 1
+	ndaLLAddr    = 2
+	sizeofNdMsg  = 12
+	nlaTypeMask  = 0x3fff
+)
+
+var nudStates = map[uint16]string{
+	0x01: "incomplete",
+	0x02: "reachable",
+	0x04: "stale",
+	0x08: "delay",
+	0x10: "probe",
+	0x20: "failed",
+	0x40: "noarp",
+	0x80: "permanent",
+}
+
+func (h *Handler) GetNeighbors(ctx context.Context, filter model.NetNeighborFilter) ([]model.NetNeighbor, error) {
+	prefixes, err := genMACPrefixes(filter.MACPrefixes)
+	if err != nil {
+		return nil, model.NewInvalidInputError(err)
+	}
+	netInterfaceBlacklist, ipNetBlacklist, err := h.getBlacklists(ctx)
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	neighbors, err := readARPTable(path.Join(h.procfsPath, arpTablePath))
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	ndpNeighbors, err := readNDPTable()
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	neighbors = append(neighbors, ndpNeighbors...)
+	var neighborList []model.NetNeighbor
+	for _, neighbor := range neighbors {
+		if h.blacklistedInterface(neighbor.Interface, netInterfaceBlacklist) {
+			continue
+		}
+		if h.blacklistedNetwork(net.ParseIP(neighbor.IPAddr), ipNetBlacklist) {
+			continue
+		}
+		if !matchMACPrefix(neighbor.MACAddr, prefixes) {
+			continue
+		}
+		neighbor.Vendor = lookupVendor(h.vendors, neighbor.MACAddr)
+		neighborList = append(neighborList, neighbor)
+	}
+	sort.Slice(neighborList, func(i, j int) bool {
+		if neighborList[i].Interface != neighborList[j].Interface {
+			return neighborList[i].Interface < neighborList[j].Interface
+		}
+		return neighborList[i].IPAddr < neighborList[j].IPAddr
+	})
+	return neighborList, nil
+}
+
+func readARPTable(p string) ([]model.NetNeighbor, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseARPTable(file)
+}
+
+// parseARPTable parses the ARP table format of procfs. Entries without a resolved hardware address are skipped.
+func parseARPTable(r io.Reader) ([]model.NetNeighbor, error) {
+	var neighbors []model.NetNeighbor
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		if first {
+			// skip header
+			first = false
+			continue
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[2], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parsing flags of '%s' failed: %s", fields[0], err)
+		}
+		if flags&atfCom == 0 {
+			continue
+		}
+		hwAddr, err := net.ParseMAC(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("parsing hardware address of '%s' failed: %s", fields[0], err)
+		}
+		state := "complete"
+		if flags&atfPerm != 0 {
+			state = "permanent"
+		}
+		neighbors = append(neighbors, model.NetNeighbor{
+			Interface: fields[5],
+			IPAddr:    fields[0],
+			MACAddr:   hwAddr.String(),
+			State:     state,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return neighbors, nil
+}
+
+func readNDPTable() ([]model.NetNeighbor, error) {
+	b, err := syscall.NetlinkRIB(syscall.RTM_GETNEIGH, syscall.AF_INET6)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	interfaces := make(map[int]string)
+	var neighbors []model.NetNeighbor
+	for _, msg := range msgs {
+		if msg.Header.Type != syscall.RTM_NEWNEIGH {
+			continue
+		}
+		index, neighbor, ok := parseNeighMsg(msg.Data)
+		if !ok {
+			continue
+		}
+		name, ok := interfaces[index]
+		if !ok {
+			itf, err := net.InterfaceByIndex(index)
+			if err != nil {
+				continue
+			}
+			name = itf.Name
+			interfaces[index] = name
+		}
+		neighbor.Interface = name
+		neighbors = append(neighbors, neighbor)
+	}
+	return neighbors, nil
+}
+
+// parseNeighMsg parses the ndmsg header and attributes of a neighbor message and returns the interface index.
+// Entries without an IPv6 address or hardware address are skipped.
+func parseNeighMsg(b []byte) (int, model.NetNeighbor, bool) {
+	if len(b) < sizeofNdMsg || b[0] != syscall.AF_INET6 {
+		return 0, model.NetNeighbor{}, false
+	}
+	index := int(int32(binary.NativeEndian.Uint32(b[4:8])))
+	state := binary.NativeEndian.Uint16(b[8:10])
+	attrs := parseAttrs(b[sizeofNdMsg:])
+	ip := net.IP(attrs[ndaDst])
+	if len(ip) != net.IPv6len {
+		return 0, model.NetNeighbor{}, false
+	}
+	hwAddr := net.HardwareAddr(attrs[ndaLLAddr])
+	if len(hwAddr) == 0 {
+		return 0, model.NetNeighbor{}, false
+	}
+	return index, model.NetNeighbor{
+		IPAddr:  ip.String(),
+		MACAddr: hwAddr.String(),
+		State:   nudStates[state],
+	}, true
+}
+
+func parseAttrs(b []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for len(b) >= syscall.SizeofRtAttr {
+		l := int(binary.NativeEndian.Uint16(b[0:2]))
+		t := binary.NativeEndian.Uint16(b[2:4]) & nlaTypeMask
+		if l < syscall.SizeofRtAttr || l > len(b) {
+			break
+		}
+		attrs[t] = b[syscall.SizeofRtAttr:l]
+		l = (l + syscall.RTA_ALIGNTO - 1) &^ (syscall.RTA_ALIGNTO - 1)
+		if l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return attrs
+}
+
+func genMACPrefixes(values []string) ([]string, error) {
+	var prefixes []string
+	for _, v := range values {
+		prefix := normalizeMAC(v)
+		if prefix == "" {
+			return nil, errors.New("empty MAC prefix")
+		}
+		for _, c := range prefix {
+			if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+				return nil, fmt.Errorf("invalid MAC prefix '%s'", v)
+			}
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func matchMACPrefix(macAddr string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	macAddr = normalizeMAC(macAddr)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(macAddr, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeMAC(v string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(v))
+}
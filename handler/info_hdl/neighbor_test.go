@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package info_hdl
+
+import (
+	"encoding/binary"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+const testARPTable = `IP address       HW type     Flags       HW address            Mask     Device
+192.168.1.1      0x1         0x2         aa:bb:cc:dd:ee:ff     *        eth0
+192.168.1.20     0x1         0x0         00:00:00:00:00:00     *        eth0
+192.168.1.30     0x1         0x6         B8:27:EB:01:02:03     *        wlan0
+`
+
+func TestParseARPTable(t *testing.T) {
+	neighbors, err := parseARPTable(strings.NewReader(testARPTable))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := []model.NetNeighbor{
+		{Interface: "eth0", IPAddr: "192.168.1.1", MACAddr: "aa:bb:cc:dd:ee:ff", State: "complete"},
+		{Interface: "wlan0", IPAddr: "192.168.1.30", MACAddr: "b8:27:eb:01:02:03", State: "permanent"},
+	}
+	if !reflect.DeepEqual(a, neighbors) {
+		t.Errorf("expected %v, got %v", a, neighbors)
+	}
+	if _, err = parseARPTable(strings.NewReader("header\n192.168.1.1 0x1 0x2 invalid * eth0\n")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestParseNeighMsg(t *testing.T) {
+	newAttr := func(t uint16, v []byte) []byte {
+		b := make([]byte, syscall.SizeofRtAttr, syscall.SizeofRtAttr+len(v)+3)
+		binary.NativeEndian.PutUint16(b[0:2], uint16(syscall.SizeofRtAttr+len(v)))
+		binary.NativeEndian.PutUint16(b[2:4], t)
+		b = append(b, v...)
+		for len(b)%syscall.RTA_ALIGNTO != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+	newMsg := func(family byte, index int32, state uint16, attrs ...[]byte) []byte {
+		b := make([]byte, sizeofNdMsg)
+		b[0] = family
+		binary.NativeEndian.PutUint32(b[4:8], uint32(index))
+		binary.NativeEndian.PutUint16(b[8:10], state)
+		for _, attr := range attrs {
+			b = append(b, attr...)
+		}
+		return b
+	}
+	dst := newAttr(ndaDst, []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	llAddr := newAttr(ndaLLAddr, []byte{0x00, 0x30, 0xde, 0x01, 0x02, 0x03})
+	t.Run("valid", func(t *testing.T) {
+		index, neighbor, ok := parseNeighMsg(newMsg(syscall.AF_INET6, 3, 0x04, dst, llAddr))
+		if !ok {
+			t.Fatal("expected entry")
+		}
+		if index != 3 {
+			t.Errorf("expected 3, got %d", index)
+		}
+		a := model.NetNeighbor{IPAddr: "fe80::1", MACAddr: "00:30:de:01:02:03", State: "stale"}
+		if !reflect.DeepEqual(a, neighbor) {
+			t.Errorf("expected %v, got %v", a, neighbor)
+		}
+	})
+	t.Run("no hardware address", func(t *testing.T) {
+		if _, _, ok := parseNeighMsg(newMsg(syscall.AF_INET6, 3, 0x01, dst)); ok {
+			t.Error("expected no entry")
+		}
+	})
+	t.Run("wrong family", func(t *testing.T) {
+		if _, _, ok := parseNeighMsg(newMsg(syscall.AF_INET, 3, 0x02, dst, llAddr)); ok {
+			t.Error("expected no entry")
+		}
+	})
+	t.Run("short", func(t *testing.T) {
+		if _, _, ok := parseNeighMsg([]byte{syscall.AF_INET6}); ok {
+			t.Error("expected no entry")
+		}
+	})
+}
+
+func TestMACPrefix(t *testing.T) {
+	prefixes, err := genMACPrefixes([]string{"B8:27:EB", "00-30-de-0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"b827eb", "0030de0"}, prefixes) {
+		t.Errorf("unexpected prefixes %v", prefixes)
+	}
+	tests := map[string]bool{
+		"b8:27:eb:01:02:03": true,
+		"00:30:de:01:02:03": true,
+		"00:30:de:11:02:03": false,
+		"aa:bb:cc:dd:ee:ff": false,
+	}
+	for macAddr, a := range tests {
+		if b := matchMACPrefix(macAddr, prefixes); a != b {
+			t.Errorf("%s: expected %v, got %v", macAddr, a, b)
+		}
+	}
+	if !matchMACPrefix("aa:bb:cc:dd:ee:ff", nil) {
+		t.Error("expected match without prefixes")
+	}
+	for _, v := range []string{"", "::", "xy:27"} {
+		if _, err = genMACPrefixes([]string{v}); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
+
+func TestLookupVendor(t *testing.T) {
+	tests := map[string]string{
+		"b8:27:eb:01:02:03": "Raspberry Pi Foundation",
+		"00:30:DE:01:02:03": "WAGO Kontakttechnik GmbH",
+		"02:42:ac:11:00:02": "",
+		"aa:bb:cc:dd:ee:ff": "",
+		"b8":                "",
+	}
+	for macAddr, a := range tests {
+		if b := lookupVendor(nil, macAddr); a != b {
+			t.Errorf("%s: expected %q, got %q", macAddr, a, b)
+		}
+	}
+}
+
+func TestHandler_LoadOUIFile(t *testing.T) {
+	p := path.Join(t.TempDir(), "oui.csv")
+	data := "Registry,Assignment,Organization Name,Organization Address\n" +
+		"MA-L,10BBCC,\"Test, Inc.\",Test Street 1\n" +
+		"MA-L,B827EB,Test Pi,Test Street 2\n" +
+		"MA-M,10BBCD1,Test M,Test Street 3\n"
+	if err := os.WriteFile(p, []byte(data), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{}
+	if err := h.LoadOUIFile(p); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"10:bb:cc:dd:ee:ff": "Test, Inc.",
+		"b8:27:eb:01:02:03": "Test Pi",
+		"00:30:de:01:02:03": "WAGO Kontakttechnik GmbH",
+		"10:bb:cd:10:00:00": "",
+	}
+	for macAddr, a := range tests {
+		if b := lookupVendor(h.vendors, macAddr); a != b {
+			t.Errorf("%s: expected %q, got %q", macAddr, a, b)
+		}
+	}
+	if err := os.WriteFile(p, []byte("invalid"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.LoadOUIFile(p); err == nil {
+		t.Error("expected error")
+	}
+	if err := h.LoadOUIFile(path.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("expected error")
+	}
+}
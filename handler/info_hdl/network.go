@@ -61,20 +61,10 @@ func (h *Handler) getNetInterfaces(ctx context.Context) ([]model.NetInterface, e
 	if err != nil {
 		return nil, err
 	}
-	netInterfaceBlacklist, err := h.netInterfaceBlacklistHdl.List(ctx)
-	if err != nil {
-		return nil, err
-	}
-	netInterfaceBlacklist = append(netInterfaceBlacklist, h.netInterfaceBlacklist...)
-	netRangeBlacklist, err := h.netRangeBlacklistHdl.List(ctx)
+	netInterfaceBlacklist, ipNetBlacklist, err := h.getBlacklists(ctx)
 	if err != nil {
 		return nil, err
 	}
-	ipNetBlacklist, err := genIPNets(netRangeBlacklist)
-	if err != nil {
-		return nil, err
-	}
-	ipNetBlacklist = append(ipNetBlacklist, h.netRangeBlacklist...)
 	var interfaces []model.NetInterface
 	for _, i := range ifs {
 		if ctx.Err() != nil {
@@ -109,6 +99,33 @@ func (h *Handler) getNetInterfaces(ctx context.Context) ([]model.NetInterface, e
 	return interfaces, nil
 }
 
+// GetBlacklistedNets returns the blacklisted network ranges.
+func (h *Handler) GetBlacklistedNets(ctx context.Context) ([]*net.IPNet, error) {
+	_, ipNetBlacklist, err := h.getBlacklists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ipNetBlacklist, nil
+}
+
+func (h *Handler) getBlacklists(ctx context.Context) ([]string, []*net.IPNet, error) {
+	netInterfaceBlacklist, err := h.netInterfaceBlacklistHdl.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	netInterfaceBlacklist = append(netInterfaceBlacklist, h.netInterfaceBlacklist...)
+	netRangeBlacklist, err := h.netRangeBlacklistHdl.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ipNetBlacklist, err := genIPNets(netRangeBlacklist)
+	if err != nil {
+		return nil, nil, err
+	}
+	ipNetBlacklist = append(ipNetBlacklist, h.netRangeBlacklist...)
+	return netInterfaceBlacklist, ipNetBlacklist, nil
+}
+
 func (h *Handler) blacklistedInterface(name string, list []string) bool {
 	for _, s := range list {
 		if strings.Contains(name, s) {
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package info_hdl
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ouiTable is a curated subset of the IEEE MA-L assignments containing vendors commonly found in building and
+// industrial automation networks. Lines have the format '<OUI as 6 hex digits><TAB><vendor>'. Additional
+// assignments can be loaded from a copy of the IEEE registry via LoadOUIFile.
+//
+//go:embed oui.txt
+var ouiTable string
+
+var ouiMap = sync.OnceValue(func() map[string]string {
+	m := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(ouiTable))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		oui, vendor, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		m[strings.ToLower(oui)] = strings.TrimSpace(vendor)
+	}
+	return m
+})
+
+// LoadOUIFile loads MA-L assignments from a file in the CSV format published by the IEEE
+// (https://standards-oui.ieee.org/oui/oui.csv). The assignments take precedence over the embedded table.
+func (h *Handler) LoadOUIFile(p string) error {
+	file, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	vendors, err := readOUICSV(file)
+	if err != nil {
+		return fmt.Errorf("reading OUI file '%s' failed: %s", p, err)
+	}
+	h.vendors = vendors
+	return nil
+}
+
+// readOUICSV reads records with the columns 'Registry,Assignment,Organization Name,...'. Records of other registries
+// than MA-L are skipped.
+func readOUICSV(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	vendors := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(record) < 3 || record[0] != "MA-L" || len(record[1]) != 6 {
+			continue
+		}
+		vendors[strings.ToLower(record[1])] = strings.TrimSpace(record[2])
+	}
+	if len(vendors) == 0 {
+		return nil, errors.New("no MA-L assignments")
+	}
+	return vendors, nil
+}
+
+// lookupVendor returns the vendor of the address from the given assignments or the embedded table.
+func lookupVendor(vendors map[string]string, macAddr string) string {
+	mac := normalizeMAC(macAddr)
+	if len(mac) < 6 {
+		return ""
+	}
+	// locally administered addresses are not assigned by the IEEE
+	if strings.IndexByte("2367abef", mac[1]) >= 0 {
+		return ""
+	}
+	if vendor, ok := vendors[mac[:6]]; ok {
+		return vendor
+	}
+	return ouiMap()[mac[:6]]
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_hdl
+
+import (
+	"context"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/libp2p/zeroconf/v2"
+	"net"
+)
+
+type zeroconfBrowser struct{}
+
+func (b zeroconfBrowser) Browse(ctx context.Context, service, domain string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error {
+	return zeroconf.Browse(ctx, service, domain, entries, getClientOpts(ifaces, ipType)...)
+}
+
+func (b zeroconfBrowser) Lookup(ctx context.Context, instance, service, domain string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error {
+	return zeroconf.Lookup(ctx, instance, service, domain, entries, getClientOpts(ifaces, ipType)...)
+}
+
+func getClientOpts(ifaces []net.Interface, ipType lib_model.MDNSIPType) []zeroconf.ClientOption {
+	opts := []zeroconf.ClientOption{zeroconf.SelectIPTraffic(ipTypeMap[ipType])}
+	if len(ifaces) > 0 {
+		opts = append(opts, zeroconf.SelectIfaces(ifaces))
+	}
+	return opts
+}
+
+var ipTypeMap = map[lib_model.MDNSIPType]zeroconf.IPType{
+	lib_model.MDNSIPv4:     zeroconf.IPv4,
+	lib_model.MDNSIPv6:     zeroconf.IPv6,
+	lib_model.MDNSIPv4And6: zeroconf.IPv4AndIPv6,
+}
@@ -19,57 +19,448 @@ package mdns_hdl
 import (
 	"context"
 	"errors"
+	"fmt"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
 	"github.com/libp2p/zeroconf/v2"
+	"net"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
-type Handler struct{}
+const servicesMetaQuery = "_services._dns-sd._udp"
 
-func New() *Handler {
-	return &Handler{}
+type Handler struct {
+	browser    Browser
+	netInfoHdl NetInfoHandler
+	cacheSize  int
+	cache      map[string]map[string]lib_model.MDNSEntry
+	mu         sync.RWMutex
 }
 
-func (h *Handler) Query(ctx context.Context, service, domain string, window time.Duration) ([]lib_model.MDNSEntry, error) {
-	var entries []lib_model.MDNSEntry
+// New creates a handler that caches at most cacheSize entries of the services browsed via RunBrowser.
+func New(cacheSize int) *Handler {
+	return NewWithBrowser(zeroconfBrowser{}, cacheSize)
+}
+
+func NewWithBrowser(browser Browser, cacheSize int) *Handler {
+	return &Handler{
+		browser:   browser,
+		cacheSize: cacheSize,
+		cache:     make(map[string]map[string]lib_model.MDNSEntry),
+	}
+}
+
+// SetNetInfoHandler limits browsing to the interfaces provided by the handler.
+func (h *Handler) SetNetInfoHandler(hdl NetInfoHandler) {
+	h.netInfoHdl = hdl
+}
+
+// Query returns the cached entries of a service browsed in the background or browses the service for the duration
+// of the window if the service is not cached, fresh is set or the query is limited to interfaces or an IP type other
+// than the default.
+func (h *Handler) Query(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSEntry, error) {
+	opts, err := h.getBrowseOpts(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseTxtFilter(options.Txt)
+	if err != nil {
+		return nil, err
+	}
+	cached := isDefaultDomain(domain) && len(options.Interfaces) == 0 && opts.ipType == lib_model.MDNSIPv4 && h.isCached(service)
+	if cached && !options.Fresh {
+		return filter.apply(h.getCached(service)), nil
+	}
+	entries, err := h.browse(ctx, service, domain, window, opts)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		h.setCached(service, entries)
+	}
+	return filter.apply(entries), nil
+}
+
+// Stream sends the entries of a service on the returned channel as soon as they are received. The channel is closed
+// after the window ended or ctx is done.
+func (h *Handler) Stream(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (<-chan lib_model.MDNSEntry, error) {
+	if service == "" {
+		return nil, lib_model.NewInvalidInputError(errors.New("missing service"))
+	}
+	opts, err := h.getBrowseOpts(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseTxtFilter(options.Txt)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(chan lib_model.MDNSEntry)
+	go func() {
+		defer close(entries)
+		ctxWt, cancel := context.WithTimeout(ctx, window)
+		defer cancel()
+		err := h.stream(ctxWt, service, domain, window, opts, func(entry lib_model.MDNSEntry) {
+			if !filter.match(entry) {
+				return
+			}
+			select {
+			case entries <- entry:
+			case <-ctxWt.Done():
+			}
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			util.Logger.Errorf("streaming mdns service '%s' failed: %s", service, err)
+		}
+	}()
+	return entries, nil
+}
+
+// Types returns the distinct service types announced on the network.
+func (h *Handler) Types(ctx context.Context, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSServiceType, error) {
+	options.Txt = nil
+	entries, err := h.Query(ctx, servicesMetaQuery, domain, window, options)
+	if err != nil {
+		return nil, err
+	}
+	typeMap := make(map[lib_model.MDNSServiceType]struct{})
+	for _, entry := range entries {
+		if srvType, ok := parseServiceType(entry.Name); ok {
+			typeMap[srvType] = struct{}{}
+		}
+	}
+	types := make([]lib_model.MDNSServiceType, 0, len(typeMap))
+	for srvType := range typeMap {
+		types = append(types, srvType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Type == types[j].Type {
+			return types[i].Domain < types[j].Domain
+		}
+		return types[i].Type < types[j].Type
+	})
+	return types, nil
+}
+
+// Lookup resolves a single service instance. A NotFoundError is returned if the instance does not respond within the
+// window.
+func (h *Handler) Lookup(ctx context.Context, instance, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (lib_model.MDNSEntry, error) {
+	if instance == "" || service == "" {
+		return lib_model.MDNSEntry{}, lib_model.NewInvalidInputError(errors.New("missing instance or service"))
+	}
+	opts, err := h.getBrowseOpts(ctx, options)
+	if err != nil {
+		return lib_model.MDNSEntry{}, err
+	}
+	entry, ok, err := h.lookup(ctx, instance, service, domain, window, opts)
+	if err != nil {
+		return lib_model.MDNSEntry{}, err
+	}
+	if !ok {
+		return lib_model.MDNSEntry{}, lib_model.NewNotFoundError(fmt.Errorf("instance '%s' of service '%s' not found", instance, service))
+	}
+	return entry, nil
+}
+
+// RunBrowser browses the services of the default domain for the duration of the window after every interval and
+// caches the results until they expire. Blocks until ctx is done.
+func (h *Handler) RunBrowser(ctx context.Context, services []string, interval, window time.Duration) {
+	h.mu.Lock()
+	for _, service := range services {
+		if _, ok := h.cache[service]; !ok {
+			h.cache[service] = make(map[string]lib_model.MDNSEntry)
+		}
+	}
+	h.mu.Unlock()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			opts, err := h.getBrowseOpts(ctx, lib_model.MDNSQueryOptions{})
+			if err != nil {
+				util.Logger.Errorf("getting mdns interfaces failed: %s", err)
+				timer.Reset(interval)
+				continue
+			}
+			for _, service := range services {
+				entries, err := h.browse(ctx, service, "", window, opts)
+				if err != nil {
+					util.Logger.Errorf("browsing mdns service '%s' failed: %s", service, err)
+					continue
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				h.setCached(service, entries)
+			}
+			timer.Reset(interval)
+		}
+	}
+}
+
+func (h *Handler) isCached(service string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	_, ok := h.cache[service]
+	return ok
+}
+
+func (h *Handler) getCached(service string) []lib_model.MDNSEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	now := time.Now()
+	entryMap := make(map[string]lib_model.MDNSEntry)
+	for name, entry := range h.cache[service] {
+		if entry.Expiry.After(now) {
+			entryMap[name] = entry
+		}
+	}
+	return sortEntries(entryMap)
+}
+
+// setCached adds or refreshes the entries of a service and removes expired entries. If the cache is full the entries
+// expiring first are evicted.
+func (h *Handler) setCached(service string, entries []lib_model.MDNSEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	count := 0
+	for _, srvEntries := range h.cache {
+		for name, entry := range srvEntries {
+			if !entry.Expiry.After(now) {
+				delete(srvEntries, name)
+				continue
+			}
+			count++
+		}
+	}
+	srvEntries := h.cache[service]
+	for _, entry := range entries {
+		if !entry.Expiry.After(now) {
+			continue
+		}
+		if _, ok := srvEntries[entry.Name]; !ok {
+			if count >= h.cacheSize && !h.evict(entry.Expiry) {
+				continue
+			}
+			count++
+		}
+		srvEntries[entry.Name] = entry
+	}
+}
+
+// evict removes the entry expiring first if it expires before the given time. Must be called with the lock held.
+func (h *Handler) evict(expiry time.Time) bool {
+	var srvKey, nameKey string
+	for service, srvEntries := range h.cache {
+		for name, entry := range srvEntries {
+			if entry.Expiry.Before(expiry) {
+				expiry = entry.Expiry
+				srvKey = service
+				nameKey = name
+			}
+		}
+	}
+	if srvKey == "" {
+		return false
+	}
+	delete(h.cache[srvKey], nameKey)
+	return true
+}
+
+// browse collects the entries of a service for the duration of the window. Entries are de-duplicated by instance
+// name, later results replace earlier ones.
+func (h *Handler) browse(ctx context.Context, service, domain string, window time.Duration, opts browseOpts) ([]lib_model.MDNSEntry, error) {
+	entryMap := make(map[string]lib_model.MDNSEntry)
+	err := h.stream(ctx, service, domain, window, opts, func(entry lib_model.MDNSEntry) {
+		entryMap[entry.Name] = entry
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortEntries(entryMap), nil
+}
+
+// stream calls f for every entry received while browsing the service for the duration of the window. Calls to f
+// happen sequentially and not after stream returned.
+func (h *Handler) stream(ctx context.Context, service, domain string, window time.Duration, opts browseOpts, f func(lib_model.MDNSEntry)) error {
+	if opts.restricted && len(opts.ifaces) == 0 {
+		return nil
+	}
+	return collect(ctx, window, func(ctx context.Context, results chan<- *zeroconf.ServiceEntry) error {
+		return h.browser.Browse(ctx, service, domain, opts.ifaces, opts.ipType, results)
+	}, f)
+}
+
+// lookup returns the first entry received for the instance within the window.
+func (h *Handler) lookup(ctx context.Context, instance, service, domain string, window time.Duration, opts browseOpts) (lib_model.MDNSEntry, bool, error) {
+	if opts.restricted && len(opts.ifaces) == 0 {
+		return lib_model.MDNSEntry{}, false, nil
+	}
+	ctxCf, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var entry lib_model.MDNSEntry
+	found := false
+	err := collect(ctxCf, window, func(ctx context.Context, results chan<- *zeroconf.ServiceEntry) error {
+		return h.browser.Lookup(ctx, instance, service, domain, opts.ifaces, opts.ipType, results)
+	}, func(e lib_model.MDNSEntry) {
+		if !found {
+			entry = e
+			found = true
+			cancel()
+		}
+	})
+	if found {
+		return entry, true, nil
+	}
+	return lib_model.MDNSEntry{}, false, err
+}
+
+// collect calls f for every entry sent by run within the window.
+func collect(ctx context.Context, window time.Duration, run func(ctx context.Context, results chan<- *zeroconf.ServiceEntry) error, f func(lib_model.MDNSEntry)) error {
 	results := make(chan *zeroconf.ServiceEntry)
+	stop := make(chan struct{})
+	done := make(chan struct{})
 	go func() {
-		for result := range results {
-			entries = append(entries, newMDNSEntry(result))
+		defer close(done)
+		for {
+			select {
+			case result, ok := <-results:
+				if !ok {
+					return
+				}
+				f(newMDNSEntry(result))
+			case <-stop:
+				return
+			}
 		}
 	}()
 	ctxWt, cancel := context.WithTimeout(ctx, window)
 	defer cancel()
-	var err error
-	if err = zeroconf.Browse(ctxWt, service, domain, results, zeroconf.SelectIPTraffic(zeroconf.IPv4)); err == nil {
+	err := run(ctxWt, results)
+	// the browser does not send after returning, so all entries have been received once the collector stopped
+	close(stop)
+	<-done
+	if err == nil {
 		err = ctxWt.Err()
 	}
 	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
-		return nil, lib_model.NewInternalError(err)
+		return lib_model.NewInternalError(err)
 	}
-	return entries, nil
+	return nil
+}
+
+type browseOpts struct {
+	ifaces     []net.Interface
+	restricted bool // browse only the given interfaces, nothing if empty
+	ipType     lib_model.MDNSIPType
+}
+
+// getBrowseOpts returns the requested interfaces or all interfaces allowed by the net info handler.
+func (h *Handler) getBrowseOpts(ctx context.Context, options lib_model.MDNSQueryOptions) (browseOpts, error) {
+	opts := browseOpts{ipType: options.IPType}
+	if opts.ipType == "" {
+		opts.ipType = lib_model.MDNSIPv4
+	}
+	if opts.ipType != lib_model.MDNSIPv4 && opts.ipType != lib_model.MDNSIPv6 && opts.ipType != lib_model.MDNSIPv4And6 {
+		return browseOpts{}, lib_model.NewInvalidInputError(fmt.Errorf("unknown ip type '%s'", opts.ipType))
+	}
+	names := options.Interfaces
+	if h.netInfoHdl != nil {
+		hostNet, err := h.netInfoHdl.GetNet(ctx)
+		if err != nil {
+			return browseOpts{}, err
+		}
+		allowed := make(map[string]struct{})
+		for _, netItf := range hostNet.Interfaces {
+			allowed[netItf.Name] = struct{}{}
+		}
+		for _, name := range names {
+			if _, ok := allowed[name]; !ok {
+				return browseOpts{}, lib_model.NewInvalidInputError(fmt.Errorf("interface '%s' not available", name))
+			}
+		}
+		if len(names) == 0 {
+			for name := range allowed {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			if h.netInfoHdl == nil {
+				return browseOpts{}, lib_model.NewInvalidInputError(fmt.Errorf("interface '%s': %s", name, err))
+			}
+			continue
+		}
+		opts.ifaces = append(opts.ifaces, *iface)
+	}
+	opts.restricted = h.netInfoHdl != nil || len(names) > 0
+	return opts, nil
+}
+
+func sortEntries(entryMap map[string]lib_model.MDNSEntry) []lib_model.MDNSEntry {
+	var entries []lib_model.MDNSEntry
+	for _, entry := range entryMap {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// parseServiceType splits the name of a service type enumeration entry (e.g. '_http._tcp.local') into type and domain.
+func parseServiceType(name string) (lib_model.MDNSServiceType, bool) {
+	parts := strings.SplitN(name, ".", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "_") || (parts[1] != "_tcp" && parts[1] != "_udp") {
+		return lib_model.MDNSServiceType{}, false
+	}
+	srvType := lib_model.MDNSServiceType{Type: parts[0] + "." + parts[1]}
+	if len(parts) == 3 {
+		srvType.Domain = parts[2]
+	}
+	return srvType, true
+}
+
+func isDefaultDomain(domain string) bool {
+	return domain == "" || strings.TrimSuffix(domain, ".") == "local"
 }
 
 func newMDNSEntry(se *zeroconf.ServiceEntry) lib_model.MDNSEntry {
 	var IPv4Addr string
-	if len(se.AddrIPv4) > 0 {
-		IPv4Addr = se.AddrIPv4[0].String()
+	var IPv4Addrs, IPv6Addrs []string
+	for _, ip := range se.AddrIPv4 {
+		IPv4Addrs = append(IPv4Addrs, ip.String())
+	}
+	for _, ip := range se.AddrIPv6 {
+		IPv6Addrs = append(IPv6Addrs, ip.String())
 	}
-	hostname := se.HostName
-	hostnameParts := strings.Split(hostname, ".")
-	if len(hostnameParts) > 0 {
-		hostname = hostnameParts[0]
+	if len(IPv4Addrs) > 0 {
+		IPv4Addr = IPv4Addrs[0]
 	}
+	fqdn := strings.TrimSuffix(se.HostName, ".")
+	hostname, _, _ := strings.Cut(fqdn, ".")
 	return lib_model.MDNSEntry{
 		Name:       se.Instance,
 		Type:       se.Service,
 		Subtypes:   se.Subtypes,
 		Domain:     se.Domain,
 		Hostname:   hostname,
+		FQDN:       fqdn,
 		Port:       se.Port,
 		IPv4Addr:   IPv4Addr,
+		IPv4Addrs:  IPv4Addrs,
+		IPv6Addrs:  IPv6Addrs,
 		TxtRecords: se.Text,
+		Txt:        parseTxtRecords(se.Text),
 		Expiry:     se.Expiry,
 	}
 }
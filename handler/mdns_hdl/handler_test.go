@@ -0,0 +1,313 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_hdl
+
+import (
+	"context"
+	"errors"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/libp2p/zeroconf/v2"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testBrowser struct {
+	entries []*zeroconf.ServiceEntry
+	err     error
+	calls   int
+	ifaces  []net.Interface
+	ipType  lib_model.MDNSIPType
+	mu      sync.Mutex
+}
+
+func (b *testBrowser) Browse(ctx context.Context, _, _ string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error {
+	b.mu.Lock()
+	b.calls++
+	b.ifaces = ifaces
+	b.ipType = ipType
+	b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	defer close(entries)
+	for _, entry := range b.entries {
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (b *testBrowser) Lookup(ctx context.Context, instance, service, domain string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error {
+	var instEntries []*zeroconf.ServiceEntry
+	for _, entry := range b.entries {
+		if entry.Instance == instance {
+			instEntries = append(instEntries, entry)
+		}
+	}
+	lb := &testBrowser{entries: instEntries, err: b.err}
+	return lb.Browse(ctx, service, domain, ifaces, ipType, entries)
+}
+
+func newTestEntry(instance string, port int) *zeroconf.ServiceEntry {
+	return &zeroconf.ServiceEntry{
+		ServiceRecord: zeroconf.ServiceRecord{
+			Instance: instance,
+			Service:  "_http._tcp",
+			Domain:   "local.",
+		},
+		HostName: instance + ".local.",
+		Port:     port,
+		AddrIPv4: []net.IP{net.IPv4(192, 168, 1, 10), net.IPv4(10, 0, 0, 10)},
+		AddrIPv6: []net.IP{net.ParseIP("fe80::1")},
+		Expiry:   time.Now().Add(time.Minute),
+	}
+}
+
+func TestHandler_Query(t *testing.T) {
+	browser := &testBrowser{entries: []*zeroconf.ServiceEntry{
+		newTestEntry("b", 80),
+		newTestEntry("a", 80),
+		newTestEntry("b", 8080),
+	}}
+	h := NewWithBrowser(browser, 10)
+	entries, err := h.Query(context.Background(), "_http._tcp", "", 50*time.Millisecond, lib_model.MDNSQueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name != "a" || entries[1].Name != "b" || entries[1].Port != 8080 {
+		t.Errorf("expected de-duplicated entries a and b, got %+v", entries)
+	}
+	if entries[0].Hostname != "a" || entries[0].IPv4Addr != "192.168.1.10" || len(entries[0].IPv4Addrs) != 2 || entries[0].IPv6Addrs[0] != "fe80::1" {
+		t.Errorf("wrong entry %+v", entries[0])
+	}
+	t.Run("error", func(t *testing.T) {
+		h := NewWithBrowser(&testBrowser{err: errors.New("test")}, 10)
+		_, err := h.Query(context.Background(), "_http._tcp", "", 50*time.Millisecond, lib_model.MDNSQueryOptions{})
+		var ie *lib_model.InternalError
+		if !errors.As(err, &ie) {
+			t.Errorf("expected InternalError, got %v", err)
+		}
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cf := context.WithCancel(context.Background())
+		cf()
+		if _, err := h.Query(ctx, "_http._tcp", "", time.Second, lib_model.MDNSQueryOptions{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestHandler_RunBrowser(t *testing.T) {
+	browser := &testBrowser{entries: []*zeroconf.ServiceEntry{newTestEntry("a", 80)}}
+	h := NewWithBrowser(browser, 10)
+	ctx, cf := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.RunBrowser(ctx, []string{"_http._tcp"}, time.Hour, 10*time.Millisecond)
+	}()
+	for i := 0; i < 100 && len(h.getCached("_http._tcp")) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cf()
+	<-done
+	entries, err := h.Query(context.Background(), "_http._tcp", "local.", time.Second, lib_model.MDNSQueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || browser.calls != 1 {
+		t.Errorf("expected cached entry without browsing, got %+v and %d calls", entries, browser.calls)
+	}
+	browser.entries = append(browser.entries, newTestEntry("b", 80))
+	if entries, err = h.Query(context.Background(), "_http._tcp", "", 10*time.Millisecond, lib_model.MDNSQueryOptions{Fresh: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || browser.calls != 2 || len(h.getCached("_http._tcp")) != 2 {
+		t.Errorf("expected fresh entries to be cached, got %+v and %d calls", entries, browser.calls)
+	}
+}
+
+func TestHandler_Cache(t *testing.T) {
+	h := New(2)
+	h.cache["_http._tcp"] = make(map[string]lib_model.MDNSEntry)
+	now := time.Now()
+	h.setCached("_http._tcp", []lib_model.MDNSEntry{
+		{Name: "b", Expiry: now.Add(time.Minute)},
+		{Name: "a", Expiry: now.Add(2 * time.Minute)},
+		{Name: "expired", Expiry: now.Add(-time.Second)},
+	})
+	entries := h.getCached("_http._tcp")
+	if len(entries) != 2 || entries[0].Name != "a" || entries[1].Name != "b" {
+		t.Errorf("expected entries a and b, got %+v", entries)
+	}
+	t.Run("evict entry expiring first", func(t *testing.T) {
+		h.setCached("_http._tcp", []lib_model.MDNSEntry{{Name: "c", Expiry: now.Add(3 * time.Minute)}})
+		entries = h.getCached("_http._tcp")
+		if len(entries) != 2 || entries[0].Name != "a" || entries[1].Name != "c" {
+			t.Errorf("expected entries a and c, got %+v", entries)
+		}
+	})
+	t.Run("skip entry expiring before cached entries", func(t *testing.T) {
+		h.setCached("_http._tcp", []lib_model.MDNSEntry{{Name: "d", Expiry: now.Add(time.Minute)}})
+		entries = h.getCached("_http._tcp")
+		if len(entries) != 2 || entries[0].Name != "a" || entries[1].Name != "c" {
+			t.Errorf("expected entries a and c, got %+v", entries)
+		}
+	})
+	t.Run("refresh existing entry", func(t *testing.T) {
+		h.setCached("_http._tcp", []lib_model.MDNSEntry{{Name: "a", Port: 80, Expiry: now.Add(4 * time.Minute)}})
+		entries = h.getCached("_http._tcp")
+		if len(entries) != 2 || entries[0].Port != 80 {
+			t.Errorf("expected refreshed entry a, got %+v", entries)
+		}
+	})
+	if !h.isCached("_http._tcp") || h.isCached("_ipp._tcp") {
+		t.Error("wrong cached services")
+	}
+	if !isDefaultDomain("") || !isDefaultDomain("local.") || isDefaultDomain("example.org") {
+		t.Error("wrong default domain detection")
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	browser := &testBrowser{entries: []*zeroconf.ServiceEntry{newTestEntry("a", 80), newTestEntry("b", 80)}}
+	h := NewWithBrowser(browser, 10)
+	entries, err := h.Stream(context.Background(), "_http._tcp", "", 50*time.Millisecond, lib_model.MDNSQueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for entry := range entries {
+		names = append(names, entry.Name)
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("expected entries a and b, got %v", names)
+	}
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cf := context.WithCancel(context.Background())
+		entries, err := h.Stream(ctx, "_http._tcp", "", time.Hour, lib_model.MDNSQueryOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-entries
+		cf()
+		for range entries {
+		}
+	})
+	t.Run("missing service", func(t *testing.T) {
+		var iie *lib_model.InvalidInputError
+		if _, err := h.Stream(context.Background(), "", "", time.Second, lib_model.MDNSQueryOptions{}); !errors.As(err, &iie) {
+			t.Errorf("expected InvalidInputError, got %v", err)
+		}
+	})
+}
+
+type testNetInfoHdl struct {
+	interfaces []string
+}
+
+func (h *testNetInfoHdl) GetNet(_ context.Context) (lib_model.HostNet, error) {
+	var hostNet lib_model.HostNet
+	for _, name := range h.interfaces {
+		hostNet.Interfaces = append(hostNet.Interfaces, lib_model.NetInterface{Name: name})
+	}
+	return hostNet, nil
+}
+
+func TestHandler_QueryInterfaces(t *testing.T) {
+	netItf, err := net.InterfaceByIndex(1)
+	if err != nil {
+		t.Skip(err)
+	}
+	browser := &testBrowser{entries: []*zeroconf.ServiceEntry{newTestEntry("a", 80)}}
+	netInfoHdl := &testNetInfoHdl{}
+	h := NewWithBrowser(browser, 10)
+	h.SetNetInfoHandler(netInfoHdl)
+	t.Run("no interfaces", func(t *testing.T) {
+		entries, err := h.Query(context.Background(), "_http._tcp", "", time.Second, lib_model.MDNSQueryOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 || browser.calls != 0 {
+			t.Errorf("expected no browsing, got %+v and %d calls", entries, browser.calls)
+		}
+	})
+	netInfoHdl.interfaces = []string{netItf.Name}
+	t.Run("allowed interfaces", func(t *testing.T) {
+		entries, err := h.Query(context.Background(), "_http._tcp", "", 10*time.Millisecond, lib_model.MDNSQueryOptions{IPType: lib_model.MDNSIPv4And6})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || len(browser.ifaces) != 1 || browser.ifaces[0].Name != netItf.Name || browser.ipType != lib_model.MDNSIPv4And6 {
+			t.Errorf("expected browsing on '%s', got %+v and %+v", netItf.Name, browser.ifaces, browser.ipType)
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		var iie *lib_model.InvalidInputError
+		for _, options := range []lib_model.MDNSQueryOptions{{Interfaces: []string{"blacklisted"}}, {IPType: "test"}} {
+			if _, err := h.Query(context.Background(), "_http._tcp", "", time.Second, options); !errors.As(err, &iie) {
+				t.Errorf("expected InvalidInputError for %+v, got %v", options, err)
+			}
+		}
+	})
+}
+
+func TestHandler_Types(t *testing.T) {
+	var entries []*zeroconf.ServiceEntry
+	for _, name := range []string{"_http._tcp.local", "_ipp._tcp.local", "_http._tcp.local", "invalid"} {
+		entries = append(entries, &zeroconf.ServiceEntry{ServiceRecord: zeroconf.ServiceRecord{Instance: name}, Expiry: time.Now().Add(time.Minute)})
+	}
+	h := NewWithBrowser(&testBrowser{entries: entries}, 10)
+	types, err := h.Types(context.Background(), "", 10*time.Millisecond, lib_model.MDNSQueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := []lib_model.MDNSServiceType{{Type: "_http._tcp", Domain: "local"}, {Type: "_ipp._tcp", Domain: "local"}}
+	if !reflect.DeepEqual(a, types) {
+		t.Errorf("got %+v, expected %+v", types, a)
+	}
+}
+
+func TestHandler_Lookup(t *testing.T) {
+	h := NewWithBrowser(&testBrowser{entries: []*zeroconf.ServiceEntry{newTestEntry("a", 80), newTestEntry("b", 8080)}}, 10)
+	start := time.Now()
+	entry, err := h.Lookup(context.Background(), "b", "_http._tcp", "", time.Minute, lib_model.MDNSQueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Name != "b" || entry.Port != 8080 {
+		t.Errorf("wrong entry %+v", entry)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Error("expected lookup to return after first entry")
+	}
+	var nfe *lib_model.NotFoundError
+	if _, err = h.Lookup(context.Background(), "c", "_http._tcp", "", 10*time.Millisecond, lib_model.MDNSQueryOptions{}); !errors.As(err, &nfe) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+	var iie *lib_model.InvalidInputError
+	if _, err = h.Lookup(context.Background(), "", "_http._tcp", "", time.Second, lib_model.MDNSQueryOptions{}); !errors.As(err, &iie) {
+		t.Errorf("expected InvalidInputError, got %v", err)
+	}
+}
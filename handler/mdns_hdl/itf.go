@@ -0,0 +1,37 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_hdl
+
+import (
+	"context"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/libp2p/zeroconf/v2"
+	"net"
+)
+
+type Browser interface {
+	// Browse sends the entries of a service on the entries channel and blocks until ctx is done. All multicast
+	// interfaces are used if ifaces is empty. No entries are sent after Browse returned.
+	Browse(ctx context.Context, service, domain string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error
+	// Lookup works like Browse but only sends entries of the given instance.
+	Lookup(ctx context.Context, instance, service, domain string, ifaces []net.Interface, ipType lib_model.MDNSIPType, entries chan<- *zeroconf.ServiceEntry) error
+}
+
+type NetInfoHandler interface {
+	// GetNet returns the network interfaces not hidden by the interface and range blacklists.
+	GetNet(ctx context.Context) (lib_model.HostNet, error)
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_hdl
+
+import (
+	"errors"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"strings"
+)
+
+// parseTxtRecords returns the TXT records as key value pairs. Keys are case-insensitive and stored in lower case. As
+// defined in RFC 6763 only the first occurrence of a key is used and keys without a value map to an empty string.
+func parseTxtRecords(records []string) map[string]string {
+	if len(records) == 0 {
+		return nil
+	}
+	txt := make(map[string]string)
+	for _, record := range records {
+		key, value, _ := strings.Cut(record, "=")
+		if key == "" {
+			continue
+		}
+		key = strings.ToLower(key)
+		if _, ok := txt[key]; !ok {
+			txt[key] = value
+		}
+	}
+	return txt
+}
+
+// txtFilter maps keys to required values, a nil value only requires the key to be present.
+type txtFilter map[string]*string
+
+func parseTxtFilter(items []string) (txtFilter, error) {
+	filter := make(txtFilter)
+	for _, item := range items {
+		key, value, ok := strings.Cut(item, "=")
+		if key == "" {
+			return nil, lib_model.NewInvalidInputError(errors.New("empty txt filter key"))
+		}
+		key = strings.ToLower(key)
+		if ok {
+			filter[key] = &value
+		} else {
+			filter[key] = nil
+		}
+	}
+	return filter, nil
+}
+
+func (f txtFilter) match(entry lib_model.MDNSEntry) bool {
+	for key, value := range f {
+		v, ok := entry.Txt[key]
+		if !ok || (value != nil && v != *value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f txtFilter) apply(entries []lib_model.MDNSEntry) []lib_model.MDNSEntry {
+	if len(f) == 0 {
+		return entries
+	}
+	var filtered []lib_model.MDNSEntry
+	for _, entry := range entries {
+		if f.match(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_hdl
+
+import (
+	"context"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/libp2p/zeroconf/v2"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTxtRecords(t *testing.T) {
+	a := map[string]string{
+		"vendor": "acme",
+		"path":   "/a=b",
+		"flag":   "",
+		"empty":  "",
+	}
+	b := parseTxtRecords([]string{"vendor=acme", "Path=/a=b", "flag", "empty=", "VENDOR=other", "=value"})
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %v, expected %v", b, a)
+	}
+	if parseTxtRecords(nil) != nil {
+		t.Error("expected nil")
+	}
+}
+
+func TestTxtFilter(t *testing.T) {
+	entry := lib_model.MDNSEntry{Txt: map[string]string{"vendor": "acme", "flag": ""}}
+	tests := []struct {
+		items []string
+		match bool
+	}{
+		{items: nil, match: true},
+		{items: []string{"vendor=acme"}, match: true},
+		{items: []string{"Vendor=acme", "flag"}, match: true},
+		{items: []string{"flag="}, match: true},
+		{items: []string{"vendor=other"}, match: false},
+		{items: []string{"vendor=acme", "model"}, match: false},
+	}
+	for _, tc := range tests {
+		filter, err := parseTxtFilter(tc.items)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filter.match(entry) != tc.match {
+			t.Errorf("%v: expected %v", tc.items, tc.match)
+		}
+	}
+	if _, err := parseTxtFilter([]string{"=acme"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestHandler_QueryTxt(t *testing.T) {
+	a := newTestEntry("a", 80)
+	a.Text = []string{"vendor=acme"}
+	b := newTestEntry("b", 80)
+	b.Text = []string{"vendor=other"}
+	h := NewWithBrowser(&testBrowser{entries: []*zeroconf.ServiceEntry{a, b}}, 10)
+	entries, err := h.Query(context.Background(), "_http._tcp", "", 10*time.Millisecond, lib_model.MDNSQueryOptions{Txt: []string{"vendor=acme"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name != "a" || entries[0].FQDN != "a.local" || entries[0].Hostname != "a" {
+		t.Errorf("expected entry a, got %+v", entries)
+	}
+}
@@ -0,0 +1,362 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_reg_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
+	"github.com/google/uuid"
+	"net"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultDomain = "local."
+
+type Handler struct {
+	responder  Responder
+	netInfoHdl NetInfoHandler
+	path       string
+	maxTTL     time.Duration
+	services   map[string]model.MDNSService
+	servers    map[string]Server
+	regErrs    map[string]string
+	mu         sync.Mutex
+}
+
+// New creates a handler that stores services at path p. Leases longer than maxTTL are rejected unless maxTTL is 0.
+func New(p string, maxTTL time.Duration) (*Handler, error) {
+	return NewWithResponder(zeroconfResponder{}, p, maxTTL)
+}
+
+func NewWithResponder(responder Responder, p string, maxTTL time.Duration) (*Handler, error) {
+	if !path.IsAbs(p) {
+		return nil, fmt.Errorf("path '%s' not absolute", p)
+	}
+	return &Handler{
+		responder: responder,
+		path:      p,
+		maxTTL:    maxTTL,
+		services:  make(map[string]model.MDNSService),
+		servers:   make(map[string]Server),
+		regErrs:   make(map[string]string),
+	}, nil
+}
+
+// SetNetInfoHandler limits announcements to the interfaces provided by the handler.
+func (h *Handler) SetNetInfoHandler(hdl NetInfoHandler) {
+	h.netInfoHdl = hdl
+}
+
+func (h *Handler) Init() error {
+	var services map[string]model.MDNSService
+	if err := json_sto_file.Read(h.path, &services); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if services != nil {
+		h.services = services
+	}
+	return nil
+}
+
+// Run announces stored services and removes services with expired leases after every tick. Announcements are
+// stopped once ctx is done.
+func (h *Handler) Run(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		h.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			h.shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) List(_ context.Context) ([]model.MDNSService, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	var services []model.MDNSService
+	for _, s := range h.services {
+		if s.Expires.After(timestamp) {
+			services = append(services, s)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	return services, nil
+}
+
+func (h *Handler) Get(_ context.Context, id string) (model.MDNSService, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.services[id]
+	if !ok || !s.Expires.After(time.Now()) {
+		return model.MDNSService{}, newNotFoundErr(id)
+	}
+	return s, nil
+}
+
+// Add announces a service for an owner until the lease expires.
+func (h *Handler) Add(ctx context.Context, base model.MDNSServiceBase, owner string, ttl time.Duration) (model.MDNSService, error) {
+	if base.Domain == "" {
+		base.Domain = defaultDomain
+	}
+	if err := validate(base); err != nil {
+		return model.MDNSService{}, err
+	}
+	if err := h.validateLease(owner, ttl); err != nil {
+		return model.MDNSService{}, err
+	}
+	ifaces, err := h.getInterfaces(ctx, base.Interfaces)
+	if err != nil {
+		return model.MDNSService{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	for _, s := range h.services {
+		if s.Expires.After(timestamp) && s.Name == base.Name && s.Type == base.Type && s.Domain == base.Domain {
+			return model.MDNSService{}, model.NewConflictError(fmt.Errorf("service '%s' of type '%s' already registered", base.Name, base.Type))
+		}
+	}
+	idObj, err := uuid.NewUUID()
+	if err != nil {
+		return model.MDNSService{}, model.NewInternalError(err)
+	}
+	s := model.MDNSService{
+		ID:              idObj.String(),
+		Owner:           owner,
+		Created:         timestamp,
+		Expires:         timestamp.Add(ttl),
+		MDNSServiceBase: base,
+	}
+	server, err := h.responder.Register(base, ifaces)
+	if err != nil {
+		return model.MDNSService{}, model.NewInternalError(err)
+	}
+	if err = h.store(s.ID, &s, timestamp); err != nil {
+		server.Shutdown()
+		return model.MDNSService{}, err
+	}
+	h.servers[s.ID] = server
+	return s, nil
+}
+
+// Renew extends the lease of a service. Only the owner of a service can renew the lease.
+func (h *Handler) Renew(_ context.Context, id, owner string, ttl time.Duration) (model.MDNSService, error) {
+	if err := h.validateLease(owner, ttl); err != nil {
+		return model.MDNSService{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	s, ok := h.services[id]
+	if !ok || !s.Expires.After(timestamp) {
+		return model.MDNSService{}, newNotFoundErr(id)
+	}
+	if s.Owner != owner {
+		return model.MDNSService{}, newOwnerErr(id, s.Owner)
+	}
+	s.Expires = timestamp.Add(ttl)
+	if err := h.store(id, &s, timestamp); err != nil {
+		return model.MDNSService{}, err
+	}
+	return s, nil
+}
+
+// Remove stops announcing a service. Only the owner of a service can remove it.
+func (h *Handler) Remove(_ context.Context, id, owner string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	s, ok := h.services[id]
+	if !ok || !s.Expires.After(timestamp) {
+		return newNotFoundErr(id)
+	}
+	if s.Owner != owner {
+		return newOwnerErr(id, s.Owner)
+	}
+	if err := h.store(id, nil, timestamp); err != nil {
+		return err
+	}
+	h.stopServer(id)
+	return nil
+}
+
+// refresh stops announcing expired services and announces services without a running server, e.g. after a restart.
+func (h *Handler) refresh(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	expired := false
+	for id, s := range h.services {
+		if !s.Expires.After(timestamp) {
+			h.stopServer(id)
+			expired = true
+			continue
+		}
+		if _, ok := h.servers[id]; ok {
+			continue
+		}
+		ifaces, err := h.getInterfaces(ctx, s.Interfaces)
+		var server Server
+		if err == nil {
+			server, err = h.responder.Register(s.MDNSServiceBase, ifaces)
+		}
+		if err != nil {
+			if h.regErrs[id] != err.Error() {
+				util.Logger.Errorf("announcing mdns service '%s' failed: %s", id, err)
+				h.regErrs[id] = err.Error()
+			}
+			continue
+		}
+		delete(h.regErrs, id)
+		h.servers[id] = server
+	}
+	if expired {
+		if err := h.store("", nil, timestamp); err != nil {
+			util.Logger.Errorf("removing expired mdns services failed: %s", err)
+		}
+	}
+}
+
+func (h *Handler) shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id := range h.servers {
+		h.stopServer(id)
+	}
+}
+
+// stopServer shuts down the server of a service if running. Must be called with the lock held.
+func (h *Handler) stopServer(id string) {
+	if server, ok := h.servers[id]; ok {
+		server.Shutdown()
+		delete(h.servers, id)
+	}
+	delete(h.regErrs, id)
+}
+
+// store writes a copy of the services with s set for id or removed if s is nil. Expired services are dropped. Must be
+// called with the lock held.
+func (h *Handler) store(id string, s *model.MDNSService, timestamp time.Time) error {
+	newServices := make(map[string]model.MDNSService)
+	for sID, service := range h.services {
+		if sID != id && service.Expires.After(timestamp) {
+			newServices[sID] = service
+		}
+	}
+	if s != nil {
+		newServices[id] = *s
+	}
+	if err := json_sto_file.Write(newServices, h.path, true); err != nil {
+		return model.NewInternalError(err)
+	}
+	h.services = newServices
+	return nil
+}
+
+// getInterfaces returns the given interfaces or all interfaces allowed by the net info handler if none are given.
+// Without a net info handler, services are announced on all multicast interfaces if none are given.
+func (h *Handler) getInterfaces(ctx context.Context, names []string) ([]net.Interface, error) {
+	if h.netInfoHdl != nil {
+		hostNet, err := h.netInfoHdl.GetNet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		allowed := make(map[string]struct{})
+		for _, netItf := range hostNet.Interfaces {
+			allowed[netItf.Name] = struct{}{}
+		}
+		for _, name := range names {
+			if _, ok := allowed[name]; !ok {
+				return nil, model.NewInvalidInputError(fmt.Errorf("interface '%s' not available", name))
+			}
+		}
+		if len(names) == 0 {
+			for name := range allowed {
+				names = append(names, name)
+			}
+		}
+	}
+	var ifaces []net.Interface
+	for _, name := range names {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			if h.netInfoHdl == nil {
+				return nil, model.NewInvalidInputError(fmt.Errorf("interface '%s': %s", name, err))
+			}
+			continue
+		}
+		ifaces = append(ifaces, *iface)
+	}
+	if h.netInfoHdl != nil && len(ifaces) == 0 {
+		// an empty list would announce the service on all multicast interfaces
+		return nil, model.NewInternalError(errors.New("no interfaces available"))
+	}
+	return ifaces, nil
+}
+
+func validate(base model.MDNSServiceBase) error {
+	if base.Name == "" {
+		return model.NewInvalidInputError(errors.New("missing name"))
+	}
+	if !strings.HasPrefix(base.Type, "_") || !(strings.HasSuffix(base.Type, "._tcp") || strings.HasSuffix(base.Type, "._udp")) {
+		return model.NewInvalidInputError(fmt.Errorf("invalid type '%s'", base.Type))
+	}
+	if base.Port < 1 || base.Port > 65535 {
+		return model.NewInvalidInputError(fmt.Errorf("invalid port '%d'", base.Port))
+	}
+	return nil
+}
+
+func (h *Handler) validateLease(owner string, ttl time.Duration) error {
+	if owner == "" {
+		return model.NewInvalidInputError(errors.New("missing owner"))
+	}
+	if ttl <= 0 {
+		return model.NewInvalidInputError(errors.New("invalid ttl"))
+	}
+	if h.maxTTL > 0 && ttl > h.maxTTL {
+		return model.NewInvalidInputError(fmt.Errorf("ttl exceeds maximum of %s", h.maxTTL))
+	}
+	return nil
+}
+
+func newNotFoundErr(id string) error {
+	return model.NewNotFoundError(fmt.Errorf("mdns service '%s' does not exist", id))
+}
+
+func newOwnerErr(id, owner string) error {
+	return model.NewConflictError(fmt.Errorf("mdns service '%s' owned by '%s'", id, owner))
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mdns_reg_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testServer struct {
+	ifaces   []net.Interface
+	shutdown bool
+}
+
+type testNetInfoHdl struct {
+	hostNet model.HostNet
+}
+
+func (h *testNetInfoHdl) GetNet(_ context.Context) (model.HostNet, error) {
+	return h.hostNet, nil
+}
+
+func (s *testServer) Shutdown() {
+	s.shutdown = true
+}
+
+type testResponder struct {
+	servers map[string]*testServer
+	mu      sync.Mutex
+}
+
+func (r *testResponder) Register(service model.MDNSServiceBase, ifaces []net.Interface) (Server, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := &testServer{ifaces: ifaces}
+	r.servers[service.Name] = s
+	return s, nil
+}
+
+func (r *testResponder) get(name string) *testServer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.servers[name]
+}
+
+func TestHandler(t *testing.T) {
+	p := path.Join(t.TempDir(), "services.json")
+	responder := &testResponder{servers: make(map[string]*testServer)}
+	h, err := NewWithResponder(responder, p, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := model.MDNSServiceBase{Name: "test", Type: "_http._tcp", Port: 8080}
+	s, err := h.Add(context.Background(), base, "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Domain != defaultDomain || s.Expires.Sub(s.Created) != time.Minute {
+		t.Errorf("wrong service %+v", s)
+	}
+	if server := responder.get("test"); server == nil || server.shutdown {
+		t.Error("expected running server")
+	}
+	t.Run("invalid input", func(t *testing.T) {
+		var iie *model.InvalidInputError
+		for _, b := range []model.MDNSServiceBase{
+			{Type: "_http._tcp", Port: 80},
+			{Name: "test", Type: "http", Port: 80},
+			{Name: "test", Type: "_http._tcp"},
+			{Name: "test", Type: "_http._tcp", Port: 80, Interfaces: []string{"does-not-exist"}},
+		} {
+			if _, err := h.Add(context.Background(), b, "owner", time.Minute); !errors.As(err, &iie) {
+				t.Errorf("expected InvalidInputError for %+v, got %v", b, err)
+			}
+		}
+		if _, err := h.Add(context.Background(), model.MDNSServiceBase{Name: "test2", Type: "_http._tcp", Port: 80}, "owner", 0); !errors.As(err, &iie) {
+			t.Errorf("expected InvalidInputError, got %v", err)
+		}
+		if _, err := h.Add(context.Background(), model.MDNSServiceBase{Name: "test2", Type: "_http._tcp", Port: 80}, "owner", 3*time.Hour); !errors.As(err, &iie) {
+			t.Errorf("expected InvalidInputError, got %v", err)
+		}
+		if _, err := h.Add(context.Background(), model.MDNSServiceBase{Name: "test2", Type: "_http._tcp", Port: 80}, "", time.Minute); !errors.As(err, &iie) {
+			t.Errorf("expected InvalidInputError, got %v", err)
+		}
+	})
+	t.Run("conflict", func(t *testing.T) {
+		var ce *model.ConflictError
+		if _, err := h.Add(context.Background(), base, "owner", time.Minute); !errors.As(err, &ce) {
+			t.Errorf("expected ConflictError, got %v", err)
+		}
+	})
+	t.Run("renew", func(t *testing.T) {
+		s2, err := h.Renew(context.Background(), s.ID, "owner", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s2.Expires.After(s.Expires) {
+			t.Error("expected extended lease")
+		}
+		var nfe *model.NotFoundError
+		if _, err = h.Renew(context.Background(), "unknown", "owner", time.Hour); !errors.As(err, &nfe) {
+			t.Errorf("expected NotFoundError, got %v", err)
+		}
+		var ce *model.ConflictError
+		if _, err = h.Renew(context.Background(), s.ID, "other", time.Hour); !errors.As(err, &ce) {
+			t.Errorf("expected ConflictError, got %v", err)
+		}
+	})
+	t.Run("restore", func(t *testing.T) {
+		responder2 := &testResponder{servers: make(map[string]*testServer)}
+		h2, err := NewWithResponder(responder2, p, 2*time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = h2.Init(); err != nil {
+			t.Fatal(err)
+		}
+		ctx, cf := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h2.Run(ctx, time.Hour)
+		}()
+		for i := 0; i < 100 && responder2.get("test") == nil; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cf()
+		<-done
+		if server := responder2.get("test"); server == nil || !server.shutdown {
+			t.Error("expected server to be started and shut down")
+		}
+	})
+	t.Run("remove", func(t *testing.T) {
+		var ce *model.ConflictError
+		if err := h.Remove(context.Background(), s.ID, "other"); !errors.As(err, &ce) {
+			t.Errorf("expected ConflictError, got %v", err)
+		}
+		if err := h.Remove(context.Background(), s.ID, "owner"); err != nil {
+			t.Fatal(err)
+		}
+		if !responder.get("test").shutdown {
+			t.Error("expected server to be shut down")
+		}
+		li, err := h.List(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(li) != 0 {
+			t.Errorf("expected empty list, got %+v", li)
+		}
+	})
+}
+
+func TestHandler_Expiry(t *testing.T) {
+	responder := &testResponder{servers: make(map[string]*testServer)}
+	h, err := NewWithResponder(responder, path.Join(t.TempDir(), "services.json"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := h.Add(context.Background(), model.MDNSServiceBase{Name: "test", Type: "_http._tcp", Port: 8080}, "owner", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	h.refresh(context.Background())
+	if !responder.get("test").shutdown {
+		t.Error("expected server to be shut down")
+	}
+	var nfe *model.NotFoundError
+	if _, err = h.Get(context.Background(), s.ID); !errors.As(err, &nfe) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+	if len(h.services) != 0 {
+		t.Error("expected expired service to be removed")
+	}
+}
+
+func TestHandler_Interfaces(t *testing.T) {
+	ifaces, err := net.Interfaces()
+	if err != nil || len(ifaces) == 0 {
+		t.Skip("no interfaces")
+	}
+	responder := &testResponder{servers: make(map[string]*testServer)}
+	h, err := NewWithResponder(responder, path.Join(t.TempDir(), "services.json"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	netInfoHdl := &testNetInfoHdl{}
+	h.SetNetInfoHandler(netInfoHdl)
+	base := model.MDNSServiceBase{Name: "test", Type: "_http._tcp", Port: 8080}
+	var ie *model.InternalError
+	if _, err = h.Add(context.Background(), base, "owner", time.Minute); !errors.As(err, &ie) {
+		t.Errorf("expected InternalError, got %v", err)
+	}
+	netInfoHdl.hostNet.Interfaces = []model.NetInterface{{Name: ifaces[0].Name}}
+	var iie *model.InvalidInputError
+	if _, err = h.Add(context.Background(), model.MDNSServiceBase{Name: "test", Type: "_http._tcp", Port: 8080, Interfaces: []string{"not-allowed"}}, "owner", time.Minute); !errors.As(err, &iie) {
+		t.Errorf("expected InvalidInputError, got %v", err)
+	}
+	if _, err = h.Add(context.Background(), base, "owner", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if server := responder.get("test"); server == nil || len(server.ifaces) != 1 || server.ifaces[0].Name != ifaces[0].Name {
+		t.Errorf("expected service to be announced on allowed interface, got %+v", server)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reservation_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
+	"os"
+	"path"
+	"sync"
+	"time"
+)
+
+type Handler struct {
+	reservations map[string]model.ResourceReservation
+	path         string
+	maxTTL       time.Duration
+	mu           sync.RWMutex
+}
+
+// New creates a handler that stores reservations at path p. Reservations longer than maxTTL are rejected unless maxTTL
+// is 0.
+func New(p string, maxTTL time.Duration) (*Handler, error) {
+	if !path.IsAbs(p) {
+		return nil, fmt.Errorf("path '%s' not absolute", p)
+	}
+	return &Handler{
+		path:         p,
+		maxTTL:       maxTTL,
+		reservations: make(map[string]model.ResourceReservation),
+	}, nil
+}
+
+func (h *Handler) Init() error {
+	var reservations map[string]model.ResourceReservation
+	if err := json_sto_file.Read(h.path, &reservations); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if reservations != nil {
+		h.reservations = reservations
+	}
+	return nil
+}
+
+func (h *Handler) List(_ context.Context) ([]model.ResourceReservation, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	timestamp := time.Now()
+	var reservations []model.ResourceReservation
+	for _, r := range h.reservations {
+		if r.Expires.After(timestamp) {
+			reservations = append(reservations, r)
+		}
+	}
+	return reservations, nil
+}
+
+func (h *Handler) Get(_ context.Context, rID string) (model.ResourceReservation, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	r, ok := h.reservations[rID]
+	if !ok || !r.Expires.After(time.Now()) {
+		return model.ResourceReservation{}, model.NewNotFoundError(fmt.Errorf("no reservation for resource '%s'", rID))
+	}
+	return r, nil
+}
+
+func (h *Handler) Reserve(_ context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error) {
+	if err := h.validate(owner, ttl); err != nil {
+		return model.ResourceReservation{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	r, ok := h.reservations[rID]
+	if ok && r.Expires.After(timestamp) {
+		if r.Owner != owner {
+			return model.ResourceReservation{}, model.NewConflictError(fmt.Errorf("resource '%s' reserved by '%s'", rID, r.Owner))
+		}
+	} else {
+		r = model.ResourceReservation{
+			ResourceID: rID,
+			Owner:      owner,
+			Created:    timestamp,
+		}
+	}
+	r.Expires = timestamp.Add(ttl)
+	if err := h.store(rID, &r, timestamp); err != nil {
+		return model.ResourceReservation{}, err
+	}
+	return r, nil
+}
+
+func (h *Handler) Renew(_ context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error) {
+	if err := h.validate(owner, ttl); err != nil {
+		return model.ResourceReservation{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	r, ok := h.reservations[rID]
+	if !ok || !r.Expires.After(timestamp) {
+		return model.ResourceReservation{}, model.NewNotFoundError(fmt.Errorf("no reservation for resource '%s'", rID))
+	}
+	if r.Owner != owner {
+		return model.ResourceReservation{}, model.NewConflictError(fmt.Errorf("resource '%s' reserved by '%s'", rID, r.Owner))
+	}
+	r.Expires = timestamp.Add(ttl)
+	if err := h.store(rID, &r, timestamp); err != nil {
+		return model.ResourceReservation{}, err
+	}
+	return r, nil
+}
+
+func (h *Handler) Release(_ context.Context, rID, owner string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	r, ok := h.reservations[rID]
+	if !ok || !r.Expires.After(timestamp) {
+		return model.NewNotFoundError(fmt.Errorf("no reservation for resource '%s'", rID))
+	}
+	if r.Owner != owner {
+		return model.NewConflictError(fmt.Errorf("resource '%s' reserved by '%s'", rID, r.Owner))
+	}
+	return h.store(rID, nil, timestamp)
+}
+
+// store writes a copy of the reservations with r set for rID or removed if r is nil. Expired reservations are dropped.
+func (h *Handler) store(rID string, r *model.ResourceReservation, timestamp time.Time) error {
+	newReservations := make(map[string]model.ResourceReservation)
+	for id, reservation := range h.reservations {
+		if id != rID && reservation.Expires.After(timestamp) {
+			newReservations[id] = reservation
+		}
+	}
+	if r != nil {
+		newReservations[rID] = *r
+	}
+	if err := json_sto_file.Write(newReservations, h.path, true); err != nil {
+		return model.NewInternalError(err)
+	}
+	h.reservations = newReservations
+	return nil
+}
+
+func (h *Handler) validate(owner string, ttl time.Duration) error {
+	if owner == "" {
+		return model.NewInvalidInputError(errors.New("missing owner"))
+	}
+	if ttl <= 0 {
+		return model.NewInvalidInputError(errors.New("invalid ttl"))
+	}
+	if h.maxTTL > 0 && ttl > h.maxTTL {
+		return model.NewInvalidInputError(fmt.Errorf("ttl exceeds maximum of %s", h.maxTTL))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reservation_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHandler_Init(t *testing.T) {
+	tmpFilePath := path.Join(t.TempDir(), "test.json")
+	t.Run("file does not exist", func(t *testing.T) {
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("file exists", func(t *testing.T) {
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		a, err := h.Reserve(context.Background(), "serial:123", "test", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h2, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h2.Init(); err != nil {
+			t.Error(err)
+		}
+		b, ok := h2.reservations["serial:123"]
+		if !ok {
+			t.Fatal("reservation not in map")
+		}
+		if !a.Expires.Equal(b.Expires) || a.Owner != b.Owner {
+			t.Errorf("got %+v, expected %+v", b, a)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		f, err := os.Create(tmpFilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		h, err := New(tmpFilePath, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = h.Init(); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestHandler_Reserve(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 24*time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+	a, err := h.Reserve(context.Background(), "serial:123", "a", time.Minute)
+	if err != nil {
+		t.Error(err)
+	}
+	if a.ResourceID != "serial:123" || a.Owner != "a" {
+		t.Errorf("invalid reservation %+v", a)
+	}
+	t.Run("same owner", func(t *testing.T) {
+		b, err := h.Reserve(context.Background(), "serial:123", "a", time.Hour)
+		if err != nil {
+			t.Error(err)
+		}
+		if !b.Created.Equal(a.Created) || !b.Expires.After(a.Expires) {
+			t.Errorf("got %+v, expected renewed %+v", b, a)
+		}
+	})
+	t.Run("conflict", func(t *testing.T) {
+		_, err = h.Reserve(context.Background(), "serial:123", "b", time.Minute)
+		var ce *model.ConflictError
+		if !errors.As(err, &ce) {
+			t.Error("expected ConflictError")
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		var ii *model.InvalidInputError
+		if _, err = h.Reserve(context.Background(), "serial:456", "", time.Minute); !errors.As(err, &ii) {
+			t.Error("expected InvalidInputError")
+		}
+		if _, err = h.Reserve(context.Background(), "serial:456", "a", 0); !errors.As(err, &ii) {
+			t.Error("expected InvalidInputError")
+		}
+		if _, err = h.Reserve(context.Background(), "serial:456", "a", 48*time.Hour); !errors.As(err, &ii) {
+			t.Error("expected InvalidInputError")
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		_, err = h.Reserve(context.Background(), "serial:789", "a", time.Millisecond)
+		if err != nil {
+			t.Error(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		_, err = h.Reserve(context.Background(), "serial:789", "b", time.Minute)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestHandler_Renew(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 0)
+	if err != nil {
+		t.Error(err)
+	}
+	t.Run("doesn't exist", func(t *testing.T) {
+		_, err = h.Renew(context.Background(), "serial:123", "a", time.Minute)
+		var nf *model.NotFoundError
+		if !errors.As(err, &nf) {
+			t.Error("expected NotFoundError")
+		}
+	})
+	a, err := h.Reserve(context.Background(), "serial:123", "a", time.Minute)
+	if err != nil {
+		t.Error(err)
+	}
+	t.Run("conflict", func(t *testing.T) {
+		_, err = h.Renew(context.Background(), "serial:123", "b", time.Minute)
+		var ce *model.ConflictError
+		if !errors.As(err, &ce) {
+			t.Error("expected ConflictError")
+		}
+	})
+	b, err := h.Renew(context.Background(), "serial:123", "a", time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+	if !b.Expires.After(a.Expires) {
+		t.Errorf("got %+v, expected renewed %+v", b, a)
+	}
+}
+
+func TestHandler_Release(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 0)
+	if err != nil {
+		t.Error(err)
+	}
+	t.Run("doesn't exist", func(t *testing.T) {
+		err = h.Release(context.Background(), "serial:123", "a")
+		var nf *model.NotFoundError
+		if !errors.As(err, &nf) {
+			t.Error("expected NotFoundError")
+		}
+	})
+	if _, err = h.Reserve(context.Background(), "serial:123", "a", time.Minute); err != nil {
+		t.Error(err)
+	}
+	t.Run("conflict", func(t *testing.T) {
+		err = h.Release(context.Background(), "serial:123", "b")
+		var ce *model.ConflictError
+		if !errors.As(err, &ce) {
+			t.Error("expected ConflictError")
+		}
+	})
+	if err = h.Release(context.Background(), "serial:123", "a"); err != nil {
+		t.Error(err)
+	}
+	if len(h.reservations) != 0 {
+		t.Error("expected empty map")
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), 0)
+	if err != nil {
+		t.Error(err)
+	}
+	timestamp := time.Now()
+	a := model.ResourceReservation{ResourceID: "serial:123", Owner: "a", Created: timestamp, Expires: timestamp.Add(time.Minute)}
+	h.reservations["serial:123"] = a
+	h.reservations["serial:456"] = model.ResourceReservation{ResourceID: "serial:456", Owner: "b", Created: timestamp, Expires: timestamp.Add(-time.Minute)}
+	b, err := h.List(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual([]model.ResourceReservation{a}, b) {
+		t.Errorf("got %+v, expected %+v", b, []model.ResourceReservation{a})
+	}
+}
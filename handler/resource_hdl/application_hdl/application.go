@@ -27,31 +27,57 @@ import (
 	"github.com/google/uuid"
 	"os"
 	"path"
+	"reflect"
+	"slices"
 	"sync"
 )
 
 type Handler struct {
-	apps      map[string]model.HostApplication
-	path      string
-	blacklist map[string]struct{}
-	mu        sync.RWMutex
+	apps         map[string]model.HostApplication
+	path         string
+	blacklist    []string
+	blacklistHdl BlacklistHandler
+	netInfoHdl   NetInfoHandler
+	discovered   map[string]model.HostApplication
+	discPaths    []string
+	discMu       sync.Mutex
+	mu           sync.RWMutex
+	health       map[string]model.HostAppHealth
+	healthMu     sync.RWMutex
 }
 
 func New(p string, socketBlacklist []string) (*Handler, error) {
 	if !path.IsAbs(p) {
 		return nil, fmt.Errorf("path '%s' not absolute", p)
 	}
-	blacklist := make(map[string]struct{})
+	var blacklist []string
 	for _, v := range socketBlacklist {
-		blacklist[v] = struct{}{}
+		pattern, err := parseBlacklistEntry(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket blacklist entry '%s': %s", v, err)
+		}
+		blacklist = append(blacklist, pattern)
 	}
 	return &Handler{
-		path:      p,
-		apps:      make(map[string]model.HostApplication),
-		blacklist: blacklist,
+		path:       p,
+		apps:       make(map[string]model.HostApplication),
+		blacklist:  blacklist,
+		health:     make(map[string]model.HostAppHealth),
+		discovered: make(map[string]model.HostApplication),
 	}, nil
 }
 
+// SetBlacklistHandler sets a handler providing blacklist entries in addition to the entries passed to New.
+func (h *Handler) SetBlacklistHandler(blacklistHdl BlacklistHandler) {
+	h.blacklistHdl = blacklistHdl
+}
+
+// SetNetInfoHandler allows TCP endpoints on the addresses of the interfaces provided by the handler. Only loopback
+// addresses are allowed if no handler is set.
+func (h *Handler) SetNetInfoHandler(hdl NetInfoHandler) {
+	h.netInfoHdl = hdl
+}
+
 func (h *Handler) Init() error {
 	var apps map[string]model.HostApplication
 	if err := json_sto_file.Read(h.path, &apps); err != nil {
@@ -71,26 +97,39 @@ func (h *Handler) Init() error {
 			return err
 		}
 	}
+	apps, err := migrateDefaults(h.path, apps)
+	if err != nil {
+		return err
+	}
 	h.apps = apps
 	return nil
 }
 
-func (h *Handler) List(_ context.Context) ([]model.HostApplication, error) {
+func (h *Handler) List(ctx context.Context) ([]model.HostApplication, error) {
+	blacklist, err := h.getBlacklist(ctx)
+	if err != nil {
+		return nil, err
+	}
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	var apps []model.HostApplication
-	for _, app := range h.apps {
+	for id, app := range h.apps {
+		app.Source = model.HostAppSourceManual
+		app.Health = h.getHealth(id)
+		app.Blacklisted = isBlacklisted(blacklist, app.EndpointType, app.Socket)
+		apps = append(apps, app)
+	}
+	for id, app := range h.discovered {
+		app.Health = h.getHealth(id)
 		apps = append(apps, app)
 	}
 	return apps, nil
 }
 
-func (h *Handler) Add(_ context.Context, appResBase model.HostApplicationBase) (string, error) {
-	if !path.IsAbs(appResBase.Socket) {
-		return "", model.NewInvalidInputError(fmt.Errorf("path '%s' not absolute", appResBase.Socket))
-	}
-	if _, ok := h.blacklist[appResBase.Socket]; ok {
-		return "", model.NewInvalidInputError(errors.New("socket not allowed"))
+func (h *Handler) Add(ctx context.Context, appResBase model.HostApplicationBase) (string, error) {
+	setDefaults(&appResBase)
+	if err := h.validate(ctx, appResBase); err != nil {
+		return "", err
 	}
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -107,12 +146,74 @@ func (h *Handler) Add(_ context.Context, appResBase model.HostApplicationBase) (
 		delete(h.apps, id)
 		return "", model.NewInternalError(err)
 	}
+	if appResBase.EndpointType == model.HostAppEndpointUnix {
+		h.removeDiscovered(appResBase.Socket)
+	}
 	return id, nil
 }
 
-func (h *Handler) Remove(_ context.Context, id string) error {
+func (h *Handler) Update(ctx context.Context, id string, appResBase model.HostApplicationBase) error {
+	setDefaults(&appResBase)
+	if err := h.validate(ctx, appResBase); err != nil {
+		return err
+	}
 	h.mu.Lock()
-	defer h.mu.Unlock()
+	err := h.update(id, func(model.HostApplicationBase) (model.HostApplicationBase, error) {
+		return appResBase, nil
+	})
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	h.Rediscover(ctx)
+	return nil
+}
+
+// Patch applies the set fields of the patch. Defaults and validation are applied like for Update, the application
+// must not change until the patched application is stored.
+func (h *Handler) Patch(ctx context.Context, id string, patch model.HostApplicationPatch) error {
+	h.mu.RLock()
+	app, err := h.getStored(id)
+	h.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	appResBase := applyPatch(app.HostApplicationBase, patch)
+	setDefaults(&appResBase)
+	if err = h.validate(ctx, appResBase); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	err = h.update(id, func(current model.HostApplicationBase) (model.HostApplicationBase, error) {
+		if !reflect.DeepEqual(current, app.HostApplicationBase) {
+			return model.HostApplicationBase{}, model.NewConflictError(fmt.Errorf("application '%s' changed during update", id))
+		}
+		return appResBase, nil
+	})
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	h.Rediscover(ctx)
+	return nil
+}
+
+func (h *Handler) Remove(ctx context.Context, id string) error {
+	h.mu.Lock()
+	err := h.remove(id)
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	h.Rediscover(ctx)
+	return nil
+}
+
+// remove deletes the application and persists the change. Must be called with the lock held.
+func (h *Handler) remove(id string) error {
+	if _, ok := h.discovered[id]; ok {
+		return newReadOnlyErr(id)
+	}
 	if _, ok := h.apps[id]; !ok {
 		return model.NewNotFoundError(fmt.Errorf("application '%s' does not exist", id))
 	}
@@ -126,20 +227,253 @@ func (h *Handler) Remove(_ context.Context, id string) error {
 		return model.NewInternalError(err)
 	}
 	h.apps = newApps
+	h.removeHealth(id)
+	return nil
+}
+
+// getStored returns a stored application. Must be called with the lock held.
+func (h *Handler) getStored(id string) (model.HostApplication, error) {
+	if _, ok := h.discovered[id]; ok {
+		return model.HostApplication{}, newReadOnlyErr(id)
+	}
+	app, ok := h.apps[id]
+	if !ok {
+		return model.HostApplication{}, model.NewNotFoundError(fmt.Errorf("application '%s' does not exist", id))
+	}
+	return app, nil
+}
+
+// update replaces the application with the result of f and persists the change. Must be called with the lock held.
+func (h *Handler) update(id string, f func(model.HostApplicationBase) (model.HostApplicationBase, error)) error {
+	app, err := h.getStored(id)
+	if err != nil {
+		return err
+	}
+	appResBase, err := f(app.HostApplicationBase)
+	if err != nil {
+		return err
+	}
+	newApps := make(map[string]model.HostApplication)
+	for i, a := range h.apps {
+		newApps[i] = a
+	}
+	newApps[id] = model.HostApplication{
+		ID:                  id,
+		HostApplicationBase: appResBase,
+	}
+	if err = json_sto_file.Write(newApps, h.path, true); err != nil {
+		return model.NewInternalError(err)
+	}
+	h.apps = newApps
+	if appResBase.Socket != app.Socket || appResBase.EndpointType != app.EndpointType || !reflect.DeepEqual(appResBase.HealthCheck, app.HealthCheck) {
+		h.removeHealth(id)
+	}
+	return nil
+}
+
+func (h *Handler) validate(ctx context.Context, appResBase model.HostApplicationBase) error {
+	if err := validateEndpoint(appResBase.EndpointType, appResBase.Socket); err != nil {
+		return model.NewInvalidInputError(err)
+	}
+	if appResBase.EndpointType == model.HostAppEndpointTCP {
+		if err := h.validateTCPHost(ctx, appResBase.Socket); err != nil {
+			return err
+		}
+	}
+	blacklist, err := h.getBlacklist(ctx)
+	if err != nil {
+		return err
+	}
+	if isBlacklisted(blacklist, appResBase.EndpointType, appResBase.Socket) {
+		return model.NewInvalidInputError(errors.New("socket not allowed"))
+	}
+	if err = validateMetadata(appResBase); err != nil {
+		return model.NewInvalidInputError(err)
+	}
 	return nil
 }
 
-func (h *Handler) Get(_ context.Context) (map[string]model.HostResourceBase, error) {
+// validateMetadata checks the protocol, labels and health check of an application.
+func validateMetadata(appResBase model.HostApplicationBase) error {
+	switch appResBase.Protocol {
+	case model.HostAppProtocolHTTP:
+		if appResBase.BasePath != "" && appResBase.BasePath[0] != '/' {
+			return model.NewInvalidInputError(fmt.Errorf("base path '%s' must start with '/'", appResBase.BasePath))
+		}
+	case model.HostAppProtocolGRPC, model.HostAppProtocolRaw:
+		if appResBase.BasePath != "" {
+			return model.NewInvalidInputError(fmt.Errorf("base path not supported for protocol '%s'", appResBase.Protocol))
+		}
+	default:
+		return model.NewInvalidInputError(fmt.Errorf("unknown protocol '%s'", appResBase.Protocol))
+	}
+	for k := range appResBase.Labels {
+		if k == "" {
+			return model.NewInvalidInputError(errors.New("empty label key"))
+		}
+	}
+	if err := validateHealthCheck(appResBase.HealthCheck); err != nil {
+		return model.NewInvalidInputError(err)
+	}
+	return nil
+}
+
+// Get returns the registered and discovered applications as resources. Applications with blacklisted sockets are
+// omitted.
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	blacklist, err := h.getBlacklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	resources := make(map[string]model.HostResourceBase)
 	for id, app := range h.apps {
-		resources[id] = model.HostResourceBase{
-			Name: app.Name,
-			Path: app.Socket,
+		if !isBlacklisted(blacklist, app.EndpointType, app.Socket) {
+			resources[id] = getResource(app.HostApplicationBase, model.HostAppSourceManual)
+		}
+	}
+	for id, app := range h.discovered {
+		if !isBlacklisted(blacklist, app.EndpointType, app.Socket) {
+			resources[id] = getResource(app.HostApplicationBase, model.HostAppSourceDiscovered)
 		}
 	}
 	return resources, nil
 }
 
+// allApps returns the registered and discovered applications. Must be called with the lock held.
+func (h *Handler) allApps() map[string]model.HostApplication {
+	apps := make(map[string]model.HostApplication, len(h.apps)+len(h.discovered))
+	for id, app := range h.apps {
+		apps[id] = app
+	}
+	for id, app := range h.discovered {
+		apps[id] = app
+	}
+	return apps
+}
+
+// removeDiscovered removes a discovered application that is superseded by a registered application. Must be called
+// with the lock held.
+func (h *Handler) removeDiscovered(socket string) {
+	for id, app := range h.discovered {
+		if app.Socket == socket {
+			delete(h.discovered, id)
+			h.removeHealth(id)
+			return
+		}
+	}
+}
+
+func getResource(appResBase model.HostApplicationBase, source model.HostAppSource) model.HostResourceBase {
+	return model.HostResourceBase{
+		Name:       appResBase.Name,
+		Tags:       append([]string{"source:" + source}, getTags(appResBase)...),
+		Path:       getEndpointURI(appResBase.EndpointType, appResBase.Socket),
+		Attributes: getAttributes(appResBase),
+	}
+}
+
+func newReadOnlyErr(id string) error {
+	return model.NewInvalidInputError(fmt.Errorf("discovered application '%s' is read-only", id))
+}
+
+// getTags returns the protocol, owner and labels as tags in the form 'protocol:<protocol>', 'owner:<owner>' and
+// '<key>=<value>'.
+func getTags(appResBase model.HostApplicationBase) []string {
+	var tags []string
+	if appResBase.Protocol != "" {
+		tags = append(tags, "protocol:"+appResBase.Protocol)
+	}
+	if appResBase.Owner != "" {
+		tags = append(tags, "owner:"+appResBase.Owner)
+	}
+	var labels []string
+	for k, v := range appResBase.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	slices.Sort(labels)
+	return append(tags, labels...)
+}
+
+func getAttributes(appResBase model.HostApplicationBase) map[string]string {
+	attributes := make(map[string]string)
+	if appResBase.Description != "" {
+		attributes["description"] = appResBase.Description
+	}
+	if appResBase.BasePath != "" {
+		attributes["base_path"] = appResBase.BasePath
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+func applyPatch(appResBase model.HostApplicationBase, patch model.HostApplicationPatch) model.HostApplicationBase {
+	if patch.Name != nil {
+		appResBase.Name = *patch.Name
+	}
+	if patch.Description != nil {
+		appResBase.Description = *patch.Description
+	}
+	if patch.Socket != nil {
+		appResBase.Socket = *patch.Socket
+	}
+	if patch.EndpointType != nil {
+		appResBase.EndpointType = *patch.EndpointType
+	}
+	if patch.Protocol != nil {
+		appResBase.Protocol = *patch.Protocol
+	}
+	if patch.BasePath != nil {
+		appResBase.BasePath = *patch.BasePath
+	}
+	if patch.Labels != nil {
+		appResBase.Labels = patch.Labels
+	}
+	if patch.Owner != nil {
+		appResBase.Owner = *patch.Owner
+	}
+	if patch.HealthCheck != nil {
+		appResBase.HealthCheck = patch.HealthCheck
+	}
+	return appResBase
+}
+
+func setDefaults(appResBase *model.HostApplicationBase) {
+	if appResBase.EndpointType == "" {
+		appResBase.EndpointType = model.HostAppEndpointUnix
+	}
+	if appResBase.Protocol == "" {
+		appResBase.Protocol = model.HostAppProtocolRaw
+	}
+}
+
+// migrateDefaults sets defaults for fields of applications stored before the fields existed.
+func migrateDefaults(p string, apps map[string]model.HostApplication) (map[string]model.HostApplication, error) {
+	newApps := make(map[string]model.HostApplication)
+	migrate := false
+	for id, app := range apps {
+		base := app.HostApplicationBase
+		setDefaults(&app.HostApplicationBase)
+		if !reflect.DeepEqual(base, app.HostApplicationBase) {
+			migrate = true
+		}
+		newApps[id] = app
+	}
+	if !migrate {
+		return apps, nil
+	}
+	if err := json_sto_file.Copy(p, p+".defaults_migration_bk"); err != nil {
+		return nil, err
+	}
+	if err := json_sto_file.Write(newApps, p, false); err != nil {
+		return nil, err
+	}
+	return newApps, nil
+}
+
 func migrateStoFile(p string) (map[string]model.HostApplication, error) {
 	if err := json_sto_file.Copy(p, p+".migration_bk"); err != nil {
 		return nil, err
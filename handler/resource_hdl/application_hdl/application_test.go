@@ -22,6 +22,7 @@ import (
 	"errors"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
 	"os"
 	"path"
 	"reflect"
@@ -31,7 +32,7 @@ import (
 func TestHandler_Init(t *testing.T) {
 	tmpFilePath := path.Join(t.TempDir(), "test.json")
 	t.Run("file does not exist", func(t *testing.T) {
-		h, err := New(tmpFilePath, "")
+		h, err := New(tmpFilePath, nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -43,8 +44,10 @@ func TestHandler_Init(t *testing.T) {
 		util.GenHash("/test/socket1"): {
 			ID: util.GenHash("/test/socket1"),
 			HostApplicationBase: model.HostApplicationBase{
-				Name:   "Test 1",
-				Socket: "/test/socket1",
+				Name:         "Test 1",
+				Socket:       "/test/socket1",
+				EndpointType: model.HostAppEndpointUnix,
+				Protocol:     model.HostAppProtocolRaw,
 			},
 		},
 	}
@@ -64,7 +67,7 @@ func TestHandler_Init(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		h, err := New(tmpFilePath, "")
+		h, err := New(tmpFilePath, nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -76,7 +79,7 @@ func TestHandler_Init(t *testing.T) {
 		}
 	})
 	t.Run("file exists", func(t *testing.T) {
-		h, err := New(tmpFilePath, "")
+		h, err := New(tmpFilePath, nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -93,7 +96,7 @@ func TestHandler_Init(t *testing.T) {
 			t.Fatal(err)
 		}
 		defer f.Close()
-		h, err := New(tmpFilePath, "")
+		h, err := New(tmpFilePath, nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -104,7 +107,7 @@ func TestHandler_Init(t *testing.T) {
 }
 
 func TestHandler_List(t *testing.T) {
-	h, err := New(path.Join(t.TempDir(), "test.json"), "")
+	h, err := New(path.Join(t.TempDir(), "test.json"), nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -125,7 +128,8 @@ func TestHandler_List(t *testing.T) {
 		},
 	}
 	a := []model.HostApplication{{
-		ID: "123",
+		ID:     "123",
+		Source: model.HostAppSourceManual,
 		HostApplicationBase: model.HostApplicationBase{
 			Name:   "Test 1",
 			Socket: "/test/socket1",
@@ -142,7 +146,7 @@ func TestHandler_List(t *testing.T) {
 }
 
 func TestHandler_Add(t *testing.T) {
-	h, err := New(path.Join(t.TempDir(), "test.json"), "")
+	h, err := New(path.Join(t.TempDir(), "test.json"), nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -156,8 +160,10 @@ func TestHandler_Add(t *testing.T) {
 	a := model.HostApplication{
 		ID: id,
 		HostApplicationBase: model.HostApplicationBase{
-			Name:   "Test",
-			Socket: "/test/socket",
+			Name:         "Test",
+			Socket:       "/test/socket",
+			EndpointType: model.HostAppEndpointUnix,
+			Protocol:     model.HostAppProtocolRaw,
 		},
 	}
 	b, ok := h.apps[id]
@@ -170,7 +176,7 @@ func TestHandler_Add(t *testing.T) {
 }
 
 func TestHandler_Remove(t *testing.T) {
-	h, err := New(path.Join(t.TempDir(), "test.json"), "")
+	h, err := New(path.Join(t.TempDir(), "test.json"), nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -194,8 +200,96 @@ func TestHandler_Remove(t *testing.T) {
 	}
 }
 
+func TestHandler_Update(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), []string{"/test/blacklisted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("doesn't exist", func(t *testing.T) {
+		err = h.Update(context.Background(), "123", model.HostApplicationBase{Name: "Test", Socket: "/test/socket"})
+		var nf *model.NotFoundError
+		if !errors.As(err, &nf) {
+			t.Error("expected NotFoundError")
+		}
+	})
+	id, err := h.Add(context.Background(), model.HostApplicationBase{Name: "Test 1", Socket: "/test/socket1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.health[id] = model.HostAppHealth{Healthy: true}
+	t.Run("invalid", func(t *testing.T) {
+		for _, base := range []model.HostApplicationBase{{Socket: "test"}, {Socket: "/test/blacklisted"}} {
+			err = h.Update(context.Background(), id, base)
+			var iie *model.InvalidInputError
+			if !errors.As(err, &iie) {
+				t.Error("expected InvalidInputError")
+			}
+		}
+	})
+	t.Run("update", func(t *testing.T) {
+		base := model.HostApplicationBase{Name: "Test 2", Socket: "/test/socket2", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolHTTP, BasePath: "/api"}
+		if err = h.Update(context.Background(), id, base); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(h.apps[id].HostApplicationBase, base) {
+			t.Errorf("got %+v, expected %+v", h.apps[id].HostApplicationBase, base)
+		}
+		if _, ok := h.health[id]; ok {
+			t.Error("expected health to be reset")
+		}
+		h2, err := New(h.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = h2.Init(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(h2.apps, h.apps) {
+			t.Errorf("got %+v, expected %+v", h2.apps, h.apps)
+		}
+	})
+}
+
+func TestHandler_Patch(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), []string{"/test/blacklisted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := h.Add(context.Background(), model.HostApplicationBase{Name: "Test 1", Socket: "/test/socket1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "Test 2"
+	if err = h.Patch(context.Background(), id, model.HostApplicationPatch{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if app := h.apps[id]; app.Name != name || app.Socket != "/test/socket1" {
+		t.Errorf("unexpected application %+v", app)
+	}
+	socket := "/test/blacklisted"
+	err = h.Patch(context.Background(), id, model.HostApplicationPatch{Socket: &socket})
+	var iie *model.InvalidInputError
+	if !errors.As(err, &iie) {
+		t.Error("expected InvalidInputError")
+	}
+	if h.apps[id].Socket != "/test/socket1" {
+		t.Error("expected socket to be unchanged")
+	}
+	empty := ""
+	if err = h.Patch(context.Background(), id, model.HostApplicationPatch{EndpointType: &empty, Protocol: &empty}); err != nil {
+		t.Fatal(err)
+	}
+	if app := h.apps[id]; app.EndpointType != model.HostAppEndpointUnix || app.Protocol != model.HostAppProtocolRaw {
+		t.Errorf("expected defaults, got %+v", app)
+	}
+	var nfe *model.NotFoundError
+	if err = h.Patch(context.Background(), "missing", model.HostApplicationPatch{Name: &name}); !errors.As(err, &nfe) {
+		t.Error("expected NotFoundError")
+	}
+}
+
 func TestHandler_Get(t *testing.T) {
-	h, err := New(path.Join(t.TempDir(), "test.json"), "")
+	h, err := New(path.Join(t.TempDir(), "test.json"), nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -211,14 +305,16 @@ func TestHandler_Get(t *testing.T) {
 	h.apps["123"] = model.HostApplication{
 		ID: "123",
 		HostApplicationBase: model.HostApplicationBase{
-			Name:   "Test 1",
-			Socket: "/test/socket1",
+			Name:         "Test 1",
+			Socket:       "/test/socket1",
+			EndpointType: model.HostAppEndpointUnix,
 		},
 	}
 	a := map[string]model.HostResourceBase{
 		"123": {
 			Name: "Test 1",
-			Path: "/test/socket1",
+			Tags: []string{"source:manual"},
+			Path: "unix:///test/socket1",
 		},
 	}
 	b, err := h.Get(context.Background())
@@ -307,3 +403,183 @@ func TestStorageMigration(t *testing.T) {
 		}
 	})
 }
+
+func TestMigrateProtocol(t *testing.T) {
+	tmpFilePath := path.Join(t.TempDir(), "test.json")
+	apps := map[string]model.HostApplication{
+		"1": {ID: "1", HostApplicationBase: model.HostApplicationBase{Name: "Test 1", Socket: "/test/socket1"}},
+		"2": {ID: "2", HostApplicationBase: model.HostApplicationBase{Name: "Test 2", Socket: "/test/socket2", Protocol: model.HostAppProtocolHTTP}},
+	}
+	f, err := os.Create(tmpFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err = json.NewEncoder(f).Encode(apps); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(tmpFilePath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if h.apps["1"].Protocol != model.HostAppProtocolRaw || h.apps["2"].Protocol != model.HostAppProtocolHTTP {
+		t.Errorf("unexpected applications %+v", h.apps)
+	}
+	if _, err = os.Stat(tmpFilePath + ".defaults_migration_bk"); err != nil {
+		t.Error(err)
+	}
+	var apps2 map[string]model.HostApplication
+	if err = json_sto_file.Read(tmpFilePath, &apps2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(h.apps, apps2) {
+		t.Errorf("got %+v, expected %+v", apps2, h.apps)
+	}
+}
+
+func TestMigrateLegacyAndDefaults(t *testing.T) {
+	tmpFilePath := path.Join(t.TempDir(), "test.json")
+	oldFmt := []model.HostApplicationBase{
+		{Name: "Test 1", Socket: "/test/socket1"},
+	}
+	f, err := os.Create(tmpFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err = json.NewEncoder(f).Encode(oldFmt); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(tmpFilePath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	id := util.GenHash("/test/socket1")
+	if h.apps[id].Protocol != model.HostAppProtocolRaw {
+		t.Errorf("unexpected applications %+v", h.apps)
+	}
+	var legacyBk []model.HostApplicationBase
+	if err = json_sto_file.Read(tmpFilePath+".migration_bk", &legacyBk); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(oldFmt, legacyBk) {
+		t.Errorf("got %+v, expected %+v", legacyBk, oldFmt)
+	}
+	var defaultsBk map[string]model.HostApplication
+	if err = json_sto_file.Read(tmpFilePath+".defaults_migration_bk", &defaultsBk); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := defaultsBk[id]; !ok || defaultsBk[id].Protocol != "" {
+		t.Errorf("unexpected backup %+v", defaultsBk)
+	}
+}
+
+func TestHandler_validate(t *testing.T) {
+	h, err := New(path.Join(t.TempDir(), "test.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := []model.HostApplicationBase{
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolRaw},
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolGRPC},
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolHTTP, BasePath: "/api"},
+		{Socket: "@test", EndpointType: model.HostAppEndpointUnixAbstract, Protocol: model.HostAppProtocolRaw},
+		{Socket: "127.0.0.1:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "[::1]:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "localhost:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+	}
+	for _, base := range valid {
+		if err = h.validate(context.Background(), base); err != nil {
+			t.Error(err)
+		}
+	}
+	invalid := []model.HostApplicationBase{
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: "test"},
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolHTTP, BasePath: "api"},
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolRaw, BasePath: "/api"},
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolRaw, Labels: map[string]string{"": "test"}},
+		{Socket: "test", EndpointType: model.HostAppEndpointUnix, Protocol: model.HostAppProtocolRaw},
+		{Socket: "test", EndpointType: model.HostAppEndpointUnixAbstract, Protocol: model.HostAppProtocolRaw},
+		{Socket: "@", EndpointType: model.HostAppEndpointUnixAbstract, Protocol: model.HostAppProtocolRaw},
+		{Socket: "127.0.0.1", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: ":8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "127.0.0.1:0", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "192.168.1.10:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "0.0.0.0:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "example.com:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw},
+		{Socket: "/test/socket", EndpointType: "test", Protocol: model.HostAppProtocolRaw},
+	}
+	for _, base := range invalid {
+		if err = h.validate(context.Background(), base); err == nil {
+			t.Errorf("expected error for %+v", base)
+		}
+	}
+	t.Run("interface address", func(t *testing.T) {
+		base := model.HostApplicationBase{Socket: "192.168.1.10:8080", EndpointType: model.HostAppEndpointTCP, Protocol: model.HostAppProtocolRaw}
+		h.SetNetInfoHandler(&testNetInfoHdl{hostNet: model.HostNet{Interfaces: []model.NetInterface{{Name: "eth0", IPv4Addr: "192.168.1.10"}}}})
+		if err = h.validate(context.Background(), base); err != nil {
+			t.Error(err)
+		}
+		base.Socket = "192.168.1.11:8080"
+		if err = h.validate(context.Background(), base); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestGetTags(t *testing.T) {
+	tags := getTags(model.HostApplicationBase{
+		Protocol: model.HostAppProtocolHTTP,
+		Owner:    "test",
+		Labels:   map[string]string{"b": "2", "a": "1"},
+	})
+	expected := []string{"protocol:http", "owner:test", "a=1", "b=2"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("got %v, expected %v", tags, expected)
+	}
+}
+
+func TestHandler_Blacklist(t *testing.T) {
+	_, err := New(path.Join(t.TempDir(), "test.json"), []string{"test"})
+	if err == nil {
+		t.Error("expected error")
+	}
+	h, err := New(path.Join(t.TempDir(), "test.json"), []string{"/test/socket", "@test", "127.0.0.1:8080", "tcp://127.0.0.1:8081", "unix-abstract:test2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blacklisted := []model.HostApplicationBase{
+		{Socket: "/test/socket", EndpointType: model.HostAppEndpointUnix},
+		{Socket: "@test", EndpointType: model.HostAppEndpointUnixAbstract},
+		{Socket: "@test2", EndpointType: model.HostAppEndpointUnixAbstract},
+		{Socket: "127.0.0.1:8080", EndpointType: model.HostAppEndpointTCP},
+		{Socket: "127.0.0.1:8081", EndpointType: model.HostAppEndpointTCP},
+	}
+	for _, base := range blacklisted {
+		if _, err = h.Add(context.Background(), base); err == nil {
+			t.Errorf("expected error for %+v", base)
+		}
+	}
+	if _, err = h.Add(context.Background(), model.HostApplicationBase{Socket: "127.0.0.1:8082", EndpointType: model.HostAppEndpointTCP}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetEndpointURI(t *testing.T) {
+	tests := map[string][2]string{
+		"unix:///run/x.sock":   {model.HostAppEndpointUnix, "/run/x.sock"},
+		"unix-abstract:x":      {model.HostAppEndpointUnixAbstract, "@x"},
+		"tcp://127.0.0.1:8080": {model.HostAppEndpointTCP, "127.0.0.1:8080"},
+	}
+	for expected, tc := range tests {
+		if uri := getEndpointURI(tc[0], tc[1]); uri != expected {
+			t.Errorf("got %s, expected %s", uri, expected)
+		}
+	}
+}
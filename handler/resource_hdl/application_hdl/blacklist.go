@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ValidateBlacklistEntry checks if v is a valid socket blacklist entry. Entries are glob patterns of endpoint URIs,
+// unix socket paths, abstract socket names starting with '@' or TCP addresses, e.g. /run/docker*.sock or 127.0.0.1:*.
+func ValidateBlacklistEntry(v string) error {
+	if _, err := parseBlacklistEntry(v); err != nil {
+		return model.NewInvalidInputError(err)
+	}
+	return nil
+}
+
+// parseBlacklistEntry returns the endpoint URI pattern for a blacklist entry.
+func parseBlacklistEntry(v string) (string, error) {
+	var pattern string
+	switch {
+	case path.IsAbs(v):
+		pattern = getEndpointURI(model.HostAppEndpointUnix, v)
+	case strings.HasPrefix(v, "@"):
+		pattern = getEndpointURI(model.HostAppEndpointUnixAbstract, v)
+	case strings.HasPrefix(v, model.HostAppEndpointUnixAbstract+":"):
+		pattern = v
+	default:
+		if u, err := url.Parse(v); err == nil && (u.Scheme == model.HostAppEndpointUnix || u.Scheme == model.HostAppEndpointTCP) {
+			pattern = v
+		} else if _, _, err = net.SplitHostPort(v); err == nil {
+			pattern = getEndpointURI(model.HostAppEndpointTCP, v)
+		} else {
+			return "", errors.New("invalid endpoint")
+		}
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", err
+	}
+	return pattern, nil
+}
+
+// getBlacklist returns the patterns passed to New and the patterns provided by the blacklist handler.
+func (h *Handler) getBlacklist(ctx context.Context) ([]string, error) {
+	if h.blacklistHdl == nil {
+		return h.blacklist, nil
+	}
+	values, err := h.blacklistHdl.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blacklist := make([]string, 0, len(h.blacklist)+len(values))
+	blacklist = append(blacklist, h.blacklist...)
+	for _, v := range values {
+		if pattern, err := parseBlacklistEntry(v); err == nil {
+			blacklist = append(blacklist, pattern)
+		}
+	}
+	return blacklist, nil
+}
+
+func isBlacklisted(blacklist []string, endpointType model.HostAppEndpointType, address string) bool {
+	uri := getEndpointURI(endpointType, canonicalAddress(endpointType, address))
+	for _, pattern := range blacklist {
+		if ok, _ := path.Match(pattern, uri); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"path"
+	"testing"
+)
+
+type testBlacklistHdl struct {
+	values []string
+}
+
+func (h *testBlacklistHdl) List(_ context.Context) ([]string, error) {
+	return h.values, nil
+}
+
+type testNetInfoHdl struct {
+	hostNet model.HostNet
+}
+
+func (h *testNetInfoHdl) GetNet(_ context.Context) (model.HostNet, error) {
+	return h.hostNet, nil
+}
+
+func TestValidateBlacklistEntry(t *testing.T) {
+	for _, v := range []string{"/run/docker*.sock", "/var/run/*", "@test*", "127.0.0.1:*", "tcp://*:8080", "unix:///run/*.sock", "unix-abstract:test"} {
+		if err := ValidateBlacklistEntry(v); err != nil {
+			t.Errorf("%s: %s", v, err)
+		}
+	}
+	for _, v := range []string{"test", "/run/[", ""} {
+		if err := ValidateBlacklistEntry(v); err == nil {
+			t.Errorf("%s: expected error", v)
+		}
+	}
+}
+
+func TestHandler_BlacklistPatterns(t *testing.T) {
+	blacklistHdl := &testBlacklistHdl{}
+	h, err := New(path.Join(t.TempDir(), "test.json"), []string{"/run/docker*.sock", "127.0.0.1:*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetBlacklistHandler(blacklistHdl)
+	blacklisted := []model.HostApplicationBase{
+		{Socket: "/run/docker.sock"},
+		{Socket: "/run/docker-test.sock"},
+		{Socket: "/run//./docker.sock"},
+		{Socket: "127.0.0.1:8080", EndpointType: model.HostAppEndpointTCP},
+		{Socket: "localhost:8080", EndpointType: model.HostAppEndpointTCP},
+	}
+	for _, base := range blacklisted {
+		if _, err = h.Add(context.Background(), base); err == nil {
+			t.Errorf("expected error for %+v", base)
+		}
+	}
+	id, err := h.Add(context.Background(), model.HostApplicationBase{Socket: "/var/run/test.sock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blacklistHdl.values = []string{"/var/run/*"}
+	apps, err := h.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].ID != id || !apps[0].Blacklisted {
+		t.Errorf("expected application to be flagged, got %+v", apps)
+	}
+	resources, err := h.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := resources[id]; ok {
+		t.Error("expected blacklisted application to be omitted from resources")
+	}
+	if _, err = h.Add(context.Background(), model.HostApplicationBase{Socket: "/var/run/test2.sock"}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/json_sto_file"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const sidecarExt = ".json"
+
+// sidecar provides the metadata of a discovered application. The file is named after the socket with an additional
+// '.json' extension, e.g. /run/mgw-apps/x.sock.json for /run/mgw-apps/x.sock.
+type sidecar struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Protocol    model.HostAppProtocol     `json:"protocol"`
+	BasePath    string                    `json:"base_path"`
+	Labels      map[string]string         `json:"labels"`
+	Owner       string                    `json:"owner"`
+	HealthCheck *model.HostAppHealthCheck `json:"health_check"`
+}
+
+// SetDiscoveryPaths sets the directories scanned for application sockets.
+func (h *Handler) SetDiscoveryPaths(paths []string) {
+	h.discPaths = paths
+}
+
+// Discover scans the discovery directories for unix sockets. Sockets that are blacklisted or registered manually are
+// skipped. Discovered applications are read-only and identified by the hash of their socket path.
+func (h *Handler) Discover(ctx context.Context) error {
+	h.discMu.Lock()
+	defer h.discMu.Unlock()
+	blacklist, err := h.getBlacklist(ctx)
+	if err != nil {
+		return err
+	}
+	h.mu.RLock()
+	registered := make(map[string]struct{})
+	for _, app := range h.apps {
+		if app.EndpointType == model.HostAppEndpointUnix {
+			registered[app.Socket] = struct{}{}
+		}
+	}
+	h.mu.RUnlock()
+	discovered := make(map[string]model.HostApplication)
+	for _, dirPath := range h.discPaths {
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Type()&fs.ModeSocket == 0 {
+				continue
+			}
+			socketPath := path.Join(dirPath, entry.Name())
+			if _, ok := registered[socketPath]; ok || isBlacklisted(blacklist, model.HostAppEndpointUnix, socketPath) {
+				continue
+			}
+			appResBase := model.HostApplicationBase{
+				Name:         entry.Name(),
+				Socket:       socketPath,
+				EndpointType: model.HostAppEndpointUnix,
+			}
+			if sc, err := readSidecar(socketPath + sidecarExt); err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					util.Logger.Warningf("reading sidecar of '%s' failed: %s", socketPath, err)
+				}
+			} else if appResBase, err = applySidecar(appResBase, sc); err != nil {
+				util.Logger.Warningf("invalid sidecar of '%s': %s", socketPath, err)
+			}
+			setDefaults(&appResBase)
+			id := util.GenHash("discovered", socketPath)
+			discovered[id] = model.HostApplication{
+				ID:                  id,
+				Source:              model.HostAppSourceDiscovered,
+				HostApplicationBase: appResBase,
+			}
+		}
+	}
+	h.mu.Lock()
+	old := h.discovered
+	h.discovered = discovered
+	h.mu.Unlock()
+	for id, app := range old {
+		if newApp, ok := discovered[id]; !ok || !reflect.DeepEqual(newApp.HealthCheck, app.HealthCheck) {
+			h.removeHealth(id)
+		}
+	}
+	return nil
+}
+
+// Rediscover runs Discover if discovery paths are set, so sockets freed by registered applications or removed
+// blacklist entries are discovered without waiting for changes to the discovery directories.
+func (h *Handler) Rediscover(ctx context.Context) {
+	if len(h.discPaths) == 0 {
+		return
+	}
+	if err := h.Discover(ctx); err != nil {
+		util.Logger.Errorf("discovering applications failed: %s", err)
+	}
+}
+
+// WatchDiscovery runs Discover on changes to the discovery directories and calls the optional onChange function
+// afterward. Blocks until ctx is done.
+func (h *Handler) WatchDiscovery(ctx context.Context, onChange func()) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(h.discPaths))
+	for i, dirPath := range h.discPaths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = dir_watcher.Watch(ctx, dirPath, time.Second, func() {
+				if err := h.Discover(ctx); err != nil {
+					util.Logger.Errorf("discovering applications failed: %s", err)
+					return
+				}
+				if onChange != nil {
+					onChange()
+				}
+			})
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func readSidecar(p string) (sidecar, error) {
+	var sc sidecar
+	if err := json_sto_file.Read(p, &sc); err != nil {
+		return sidecar{}, err
+	}
+	return sc, nil
+}
+
+// applySidecar returns the application with the sidecar metadata or the unchanged application if the metadata is
+// invalid.
+func applySidecar(appResBase model.HostApplicationBase, sc sidecar) (model.HostApplicationBase, error) {
+	newBase := appResBase
+	if sc.Name != "" {
+		newBase.Name = sc.Name
+	}
+	newBase.Description = sc.Description
+	newBase.Protocol = sc.Protocol
+	newBase.BasePath = sc.BasePath
+	newBase.Labels = sc.Labels
+	newBase.Owner = sc.Owner
+	newBase.HealthCheck = sc.HealthCheck
+	setDefaults(&newBase)
+	if err := validateMetadata(newBase); err != nil {
+		return appResBase, err
+	}
+	return newBase, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"net"
+	"os"
+	"path"
+	"testing"
+)
+
+func newTestSocket(t *testing.T, socket string) {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		listener.Close()
+	})
+}
+
+func TestHandler_Discover(t *testing.T) {
+	dir, err := os.MkdirTemp("", "app_hdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"a.sock", "b.sock", "registered.sock", "blacklisted.sock"} {
+		newTestSocket(t, path.Join(dir, name))
+	}
+	if err = os.WriteFile(path.Join(dir, "a.sock.json"), []byte(`{"name":"App A","protocol":"http","labels":{"k":"v"}}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path.Join(dir, "test.txt"), nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(path.Join(t.TempDir(), "apps.json"), []string{path.Join(dir, "blacklisted*")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetDiscoveryPaths([]string{dir, path.Join(dir, "missing")})
+	regID, err := h.Add(context.Background(), model.HostApplicationBase{Name: "registered", Socket: path.Join(dir, "registered.sock")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Discover(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	apps, err := h.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 3 {
+		t.Fatalf("expected 3 applications, got %+v", apps)
+	}
+	sources := make(map[string]model.HostApplication)
+	for _, app := range apps {
+		sources[app.Socket] = app
+	}
+	if app := sources[path.Join(dir, "registered.sock")]; app.ID != regID || app.Source != model.HostAppSourceManual {
+		t.Errorf("expected manual application, got %+v", app)
+	}
+	appA := sources[path.Join(dir, "a.sock")]
+	if appA.Source != model.HostAppSourceDiscovered || appA.Name != "App A" || appA.Protocol != model.HostAppProtocolHTTP || appA.Labels["k"] != "v" {
+		t.Errorf("expected discovered application with sidecar metadata, got %+v", appA)
+	}
+	if appB := sources[path.Join(dir, "b.sock")]; appB.Source != model.HostAppSourceDiscovered || appB.Name != "b.sock" || appB.Protocol != model.HostAppProtocolRaw {
+		t.Errorf("expected discovered application with defaults, got %+v", appB)
+	}
+	resources, err := h.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, ok := resources[appA.ID]; !ok || res.Tags[0] != "source:discovered" {
+		t.Errorf("expected discovered resource, got %+v", res)
+	}
+	var iie *model.InvalidInputError
+	if err = h.Remove(context.Background(), appA.ID); !errors.As(err, &iie) {
+		t.Errorf("expected InvalidInputError, got %v", err)
+	}
+	if err = h.Update(context.Background(), appA.ID, appA.HostApplicationBase); !errors.As(err, &iie) {
+		t.Errorf("expected InvalidInputError, got %v", err)
+	}
+	if err = os.Remove(path.Join(dir, "b.sock")); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Discover(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	apps, err = h.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 {
+		t.Errorf("expected 2 applications, got %+v", apps)
+	}
+	if appA.ID != util.GenHash("discovered", path.Join(dir, "a.sock")) {
+		t.Errorf("unexpected id '%s'", appA.ID)
+	}
+	aID, err := h.Add(context.Background(), model.HostApplicationBase{Name: "a", Socket: path.Join(dir, "a.sock")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps, err = h.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, app := range apps {
+		if app.Source != model.HostAppSourceManual {
+			t.Errorf("expected discovered application to be superseded, got %+v", app)
+		}
+	}
+	if err = h.Remove(context.Background(), aID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.discovered[appA.ID]; !ok {
+		t.Error("expected application to be discovered after removal of registered application")
+	}
+	blacklistHdl := &testBlacklistHdl{values: []string{path.Join(dir, "a.sock")}}
+	h.SetBlacklistHandler(blacklistHdl)
+	h.Rediscover(context.Background())
+	if _, ok := h.discovered[appA.ID]; ok {
+		t.Error("expected blacklisted application to be removed")
+	}
+	blacklistHdl.values = nil
+	h.Rediscover(context.Background())
+	if _, ok := h.discovered[appA.ID]; !ok {
+		t.Error("expected application to be discovered after removal of blacklist entry")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+)
+
+func validateEndpoint(endpointType model.HostAppEndpointType, address string) error {
+	switch endpointType {
+	case model.HostAppEndpointUnix:
+		if !path.IsAbs(address) {
+			return fmt.Errorf("path '%s' not absolute", address)
+		}
+	case model.HostAppEndpointUnixAbstract:
+		if !strings.HasPrefix(address, "@") || len(address) < 2 {
+			return fmt.Errorf("abstract socket name '%s' must start with '@'", address)
+		}
+	case model.HostAppEndpointTCP:
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if host == "" {
+			return fmt.Errorf("missing host in address '%s'", address)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return fmt.Errorf("invalid port '%s'", port)
+		}
+	default:
+		return fmt.Errorf("unknown endpoint type '%s'", endpointType)
+	}
+	return nil
+}
+
+// validateTCPHost checks if the host of a TCP address is a loopback address or the address of a host interface.
+func (h *Handler) validateTCPHost(ctx context.Context, address string) error {
+	host, _, err := net.SplitHostPort(canonicalAddress(model.HostAppEndpointTCP, address))
+	if err != nil {
+		return model.NewInvalidInputError(err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return model.NewInvalidInputError(fmt.Errorf("host '%s' not an IP address", host))
+	}
+	if ip.IsLoopback() {
+		return nil
+	}
+	if h.netInfoHdl != nil {
+		hostNet, err := h.netInfoHdl.GetNet(ctx)
+		if err != nil {
+			return err
+		}
+		for _, itf := range hostNet.Interfaces {
+			if itfIP := net.ParseIP(itf.IPv4Addr); itfIP != nil && itfIP.Equal(ip) {
+				return nil
+			}
+		}
+	}
+	return model.NewInvalidInputError(fmt.Errorf("host '%s' not a loopback or interface address", host))
+}
+
+// canonicalAddress cleans unix socket paths and replaces the host 'localhost' of TCP addresses with 127.0.0.1, so
+// equivalent addresses match the same blacklist patterns.
+func canonicalAddress(endpointType model.HostAppEndpointType, address string) string {
+	switch endpointType {
+	case model.HostAppEndpointUnix:
+		return path.Clean(address)
+	case model.HostAppEndpointTCP:
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return address
+		}
+		if host == "localhost" {
+			host = "127.0.0.1"
+		} else if ip := net.ParseIP(host); ip != nil {
+			host = ip.String()
+		}
+		return net.JoinHostPort(host, port)
+	default:
+		return address
+	}
+}
+
+// getEndpointURI returns the endpoint as URI: unix:///run/x.sock, unix-abstract:name or tcp://127.0.0.1:8080.
+func getEndpointURI(endpointType model.HostAppEndpointType, address string) string {
+	switch endpointType {
+	case model.HostAppEndpointUnixAbstract:
+		return endpointType + ":" + strings.TrimPrefix(address, "@")
+	default:
+		return endpointType + "://" + address
+	}
+}
+
+// getDialArgs returns the network and address for net.Dial. Go maps unix addresses starting with '@' to the
+// abstract namespace.
+func getDialArgs(endpointType model.HostAppEndpointType, address string) (string, string) {
+	if endpointType == model.HostAppEndpointTCP {
+		return "tcp", address
+	}
+	return "unix", address
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultCheckInterval = 30 * time.Second
+	defaultCheckTimeout  = 5 * time.Second
+)
+
+// RunHealthChecks checks the health of all applications with a configured health check when their interval has
+// elapsed until ctx is done.
+func (h *Handler) RunHealthChecks(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	running := make(map[string]bool)
+	done := make(chan string)
+	for {
+		h.mu.RLock()
+		for id, app := range h.allApps() {
+			if app.HealthCheck == nil || running[id] || !h.checkDue(id, *app.HealthCheck) {
+				continue
+			}
+			running[id] = true
+			go func() {
+				network, address := getDialArgs(app.EndpointType, app.Socket)
+				health := check(ctx, network, address, *app.HealthCheck)
+				if ctx.Err() == nil {
+					h.setHealth(id, health)
+				}
+				done <- id
+			}()
+		}
+		h.mu.RUnlock()
+		select {
+		case <-ctx.Done():
+			for len(running) > 0 {
+				delete(running, <-done)
+			}
+			return
+		case id := <-done:
+			delete(running, id)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) Health(_ context.Context, id string) (model.HostAppHealth, error) {
+	h.mu.RLock()
+	app, ok := h.allApps()[id]
+	h.mu.RUnlock()
+	if !ok {
+		return model.HostAppHealth{}, model.NewNotFoundError(fmt.Errorf("application '%s' does not exist", id))
+	}
+	if app.HealthCheck == nil {
+		return model.HostAppHealth{}, model.NewNotFoundError(fmt.Errorf("no health check configured for application '%s'", id))
+	}
+	h.healthMu.RLock()
+	defer h.healthMu.RUnlock()
+	health, ok := h.health[id]
+	if !ok {
+		return model.HostAppHealth{}, model.NewNotFoundError(fmt.Errorf("application '%s' not checked yet", id))
+	}
+	return health, nil
+}
+
+func (h *Handler) checkDue(id string, healthCheck model.HostAppHealthCheck) bool {
+	h.healthMu.RLock()
+	defer h.healthMu.RUnlock()
+	health, ok := h.health[id]
+	return !ok || time.Since(health.Checked) >= getInterval(healthCheck)
+}
+
+func (h *Handler) setHealth(id string, health model.HostAppHealth) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if _, ok := h.allApps()[id]; !ok {
+		return
+	}
+	h.healthMu.Lock()
+	defer h.healthMu.Unlock()
+	if !health.Healthy {
+		health.Failures = h.health[id].Failures + 1
+	}
+	h.health[id] = health
+}
+
+func (h *Handler) getHealth(id string) *model.HostAppHealth {
+	h.healthMu.RLock()
+	defer h.healthMu.RUnlock()
+	health, ok := h.health[id]
+	if !ok {
+		return nil
+	}
+	return &health
+}
+
+func (h *Handler) removeHealth(id string) {
+	h.healthMu.Lock()
+	defer h.healthMu.Unlock()
+	delete(h.health, id)
+}
+
+func check(ctx context.Context, network, address string, healthCheck model.HostAppHealthCheck) model.HostAppHealth {
+	timeout := time.Duration(healthCheck.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+	ctxWt, cf := context.WithTimeout(ctx, timeout)
+	defer cf()
+	start := time.Now()
+	var err error
+	if healthCheck.Path == "" {
+		err = connect(ctxWt, network, address)
+	} else {
+		err = get(ctxWt, network, address, healthCheck.Path)
+	}
+	health := model.HostAppHealth{
+		Healthy: err == nil,
+		Checked: start,
+		Latency: time.Since(start).Seconds(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+func connect(ctx context.Context, network, address string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func get(ctx context.Context, network, address, p string) error {
+	client := http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, address)
+			},
+			DisableKeepAlives: true,
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost"+p, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func getInterval(healthCheck model.HostAppHealthCheck) time.Duration {
+	if healthCheck.Interval <= 0 {
+		return defaultCheckInterval
+	}
+	return time.Duration(healthCheck.Interval) * time.Second
+}
+
+func validateHealthCheck(healthCheck *model.HostAppHealthCheck) error {
+	if healthCheck == nil {
+		return nil
+	}
+	if healthCheck.Path != "" && healthCheck.Path[0] != '/' {
+		return fmt.Errorf("health check path '%s' must start with '/'", healthCheck.Path)
+	}
+	if healthCheck.Interval < 0 || healthCheck.Timeout < 0 {
+		return errors.New("negative health check interval or timeout")
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T, socket string) {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+	t.Cleanup(func() {
+		srv.Close()
+	})
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := os.MkdirTemp("", "app_hdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := path.Join(dir, "test.sock")
+	newTestServer(t, socket)
+	tests := []struct {
+		name        string
+		socket      string
+		healthCheck model.HostAppHealthCheck
+		healthy     bool
+	}{
+		{name: "connect", socket: socket, healthy: true},
+		{name: "connect missing socket", socket: path.Join(dir, "missing.sock")},
+		{name: "http", socket: socket, healthCheck: model.HostAppHealthCheck{Path: "/ok"}, healthy: true},
+		{name: "http error status", socket: socket, healthCheck: model.HostAppHealthCheck{Path: "/fail"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			health := check(context.Background(), "unix", tc.socket, tc.healthCheck)
+			if health.Healthy != tc.healthy {
+				t.Errorf("expected healthy %v, got %+v", tc.healthy, health)
+			}
+			if !tc.healthy && health.Error == "" {
+				t.Error("expected error message")
+			}
+		})
+	}
+}
+
+func TestHandler_RunHealthChecks(t *testing.T) {
+	dir, err := os.MkdirTemp("", "app_hdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := path.Join(dir, "test.sock")
+	newTestServer(t, socket)
+	h, err := New(path.Join(dir, "apps.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	okID, err := h.Add(context.Background(), model.HostApplicationBase{Name: "ok", Socket: socket, HealthCheck: &model.HostAppHealthCheck{Path: "/ok", Interval: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failID, err := h.Add(context.Background(), model.HostApplicationBase{Name: "fail", Socket: socket, HealthCheck: &model.HostAppHealthCheck{Path: "/fail", Interval: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noCheckID, err := h.Add(context.Background(), model.HostApplicationBase{Name: "no check", Socket: socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cf := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		h.RunHealthChecks(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+	time.Sleep(1200 * time.Millisecond)
+	cf()
+	<-done
+	health, err := h.Health(context.Background(), okID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !health.Healthy || health.Failures != 0 {
+		t.Errorf("unexpected health %+v", health)
+	}
+	health, err = h.Health(context.Background(), failID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.Healthy || health.Failures < 2 {
+		t.Errorf("unexpected health %+v", health)
+	}
+	var nfe *model.NotFoundError
+	if _, err = h.Health(context.Background(), noCheckID); !errors.As(err, &nfe) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	apps, err := h.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, app := range apps {
+		if (app.Health != nil) != (app.HealthCheck != nil) {
+			t.Errorf("unexpected health for %s", app.Name)
+		}
+	}
+	if err = h.Remove(context.Background(), failID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.health[failID]; ok {
+		t.Error("expected health to be removed")
+	}
+}
+
+func TestValidateHealthCheck(t *testing.T) {
+	if err := validateHealthCheck(&model.HostAppHealthCheck{Path: "health"}); err == nil {
+		t.Error("expected error")
+	}
+	if err := validateHealthCheck(&model.HostAppHealthCheck{Interval: -1}); err == nil {
+		t.Error("expected error")
+	}
+	if err := validateHealthCheck(&model.HostAppHealthCheck{Path: "/health", Interval: 1}); err != nil {
+		t.Error(err)
+	}
+}
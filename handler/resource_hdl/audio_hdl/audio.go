@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio_hdl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	cardsPath      = "asound/cards"
+	sndDir         = "snd"
+	sysfsClassPath = "class/sound"
+)
+
+var (
+	cardRegex    = regexp.MustCompile(`^\s*(\d+)\s+\[(\S+)\s*\]:\s+(.+?)\s+-\s+(.+)$`)
+	sndNodeRegex = regexp.MustCompile(`^(?:control|pcm|hw|midi)C(\d+)(?:D|$)`)
+)
+
+type card struct {
+	index    string
+	id       string
+	driver   string
+	name     string
+	longName string
+}
+
+type Handler struct {
+	devPath    string
+	procfsPath string
+	sysfsPath  string
+}
+
+func New(devPath, procfsPath, sysfsPath string) *Handler {
+	return &Handler{
+		devPath:    devPath,
+		procfsPath: procfsPath,
+		sysfsPath:  sysfsPath,
+	}
+}
+
+// Watch calls onChange after sound device nodes were added or removed and blocks until ctx is done. The directory is
+// watched again once it appears if it does not exist or is removed.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchRetry(ctx, path.Join(h.devPath, sndDir), nil, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	cards, err := readCards(path.Join(h.procfsPath, cardsPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	sndNodes, err := h.getSndNodes()
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	resources := make(map[string]model.HostResourceBase)
+	for _, c := range cards {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		attributes := map[string]string{
+			"index":  c.index,
+			"id":     c.id,
+			"driver": c.driver,
+		}
+		if c.longName != "" {
+			attributes["long_name"] = c.longName
+		}
+		if nodes := sndNodes[c.index]; len(nodes) > 0 {
+			attributes["devices"] = strings.Join(nodes, ",")
+		}
+		resources[util.GenHash(c.id)] = model.HostResourceBase{
+			Name:       c.name,
+			Path:       path.Join(h.devPath, sndDir, "controlC"+c.index),
+			Attributes: attributes,
+		}
+	}
+	return resources, nil
+}
+
+func (h *Handler) getSndNodes() (map[string][]string, error) {
+	entries, err := os.ReadDir(path.Join(h.devPath, sndDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	nodes := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		sm := sndNodeRegex.FindStringSubmatch(entry.Name())
+		if sm == nil {
+			continue
+		}
+		nodes[sm[1]] = append(nodes[sm[1]], path.Join(h.devPath, sndDir, entry.Name()))
+	}
+	for _, n := range nodes {
+		sort.Strings(n)
+	}
+	return nodes, nil
+}
+
+func readCards(p string) ([]card, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var cards []card
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if sm := cardRegex.FindStringSubmatch(line); sm != nil {
+			cards = append(cards, card{
+				index:  sm[1],
+				id:     sm[2],
+				driver: sm[3],
+				name:   strings.TrimSpace(sm[4]),
+			})
+			continue
+		}
+		if len(cards) > 0 && cards[len(cards)-1].longName == "" {
+			cards[len(cards)-1].longName = strings.TrimSpace(line)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// Identities derives identities from the devices of the sound cards, so cards keep their IDs if identical cards are
+// assigned different card IDs.
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		identity, err := util.GetDeviceIdentity(path.Join(h.sysfsPath, sysfsClassPath, "card"+base.Attributes["index"], "device"))
+		if err != nil {
+			continue
+		}
+		identities[id] = identity
+	}
+	return identities, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+const testCards = ` 0 [PCH            ]: HDA-Intel - HDA Intel PCH
+                      HDA Intel PCH at 0xf7f10000 irq 32
+ 1 [Device         ]: USB-Audio - USB Audio Device
+                      C-Media Electronics Inc. USB Audio Device at usb-0000:00:14.0-1, full speed
+`
+
+func TestHandler_Get(t *testing.T) {
+	devPath := t.TempDir()
+	procfsPath := t.TempDir()
+	h := New(devPath, procfsPath, t.TempDir())
+	t.Run("cards file does not exist", func(t *testing.T) {
+		res, err := h.Get(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		if len(res) != 0 {
+			t.Error("expected empty map")
+		}
+	})
+	if err := os.MkdirAll(path.Join(procfsPath, "asound"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(procfsPath, cardsPath), []byte(testCards), 0664); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(devPath, sndDir, "by-id"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"controlC0", "pcmC0D0p", "pcmC0D0c", "hwC0D0", "controlC1", "pcmC1D0p", "seq", "timer"} {
+		if err := os.WriteFile(path.Join(devPath, sndDir, name), nil, 0660); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("PCH"): {
+			Name: "HDA Intel PCH",
+			Path: path.Join(devPath, sndDir, "controlC0"),
+			Attributes: map[string]string{
+				"index":     "0",
+				"id":        "PCH",
+				"driver":    "HDA-Intel",
+				"long_name": "HDA Intel PCH at 0xf7f10000 irq 32",
+				"devices":   path.Join(devPath, sndDir, "controlC0") + "," + path.Join(devPath, sndDir, "hwC0D0") + "," + path.Join(devPath, sndDir, "pcmC0D0c") + "," + path.Join(devPath, sndDir, "pcmC0D0p"),
+			},
+		},
+		util.GenHash("Device"): {
+			Name: "USB Audio Device",
+			Path: path.Join(devPath, sndDir, "controlC1"),
+			Attributes: map[string]string{
+				"index":     "1",
+				"id":        "Device",
+				"driver":    "USB-Audio",
+				"long_name": "C-Media Electronics Inc. USB Audio Device at usb-0000:00:14.0-1, full speed",
+				"devices":   path.Join(devPath, sndDir, "controlC1") + "," + path.Join(devPath, sndDir, "pcmC1D0p"),
+			},
+		},
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
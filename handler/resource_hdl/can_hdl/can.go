@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package can_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	sysfsClassPath = "class/net"
+	arphrdCAN      = "280"
+)
+
+type Handler struct {
+	sysfsPath    string
+	bitratesFunc func() (map[string]uint32, error)
+}
+
+func New(sysfsPath string) *Handler {
+	return &Handler{
+		sysfsPath:    sysfsPath,
+		bitratesFunc: getBitrates,
+	}
+}
+
+// Watch calls onChange after network interfaces were added, removed or changed and blocks until ctx is done. CAN
+// interfaces have no device nodes, so link changes are received via netlink.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return watchLinks(ctx, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	classPath := path.Join(h.sysfsPath, sysfsClassPath)
+	entries, err := os.ReadDir(classPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	var bitrates map[string]uint32
+	resources := make(map[string]model.HostResourceBase)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		itfPath := path.Join(classPath, entry.Name())
+		if v, err := util.ReadSysfsAttr(path.Join(itfPath, "type")); err != nil || v != arphrdCAN {
+			continue
+		}
+		if bitrates == nil {
+			if bitrates, err = h.bitratesFunc(); err != nil {
+				util.Logger.Warningf("reading CAN bitrates failed: %s", err)
+				bitrates = make(map[string]uint32)
+			}
+		}
+		attributes := make(map[string]string)
+		if v, err := util.ReadSysfsAttr(path.Join(itfPath, "ifindex")); err == nil {
+			attributes["ifindex"] = v
+		}
+		if v, err := util.ReadSysfsAttr(path.Join(itfPath, "operstate")); err == nil {
+			attributes["state"] = v
+		}
+		if v, ok := bitrates[entry.Name()]; ok && v > 0 {
+			attributes["bitrate"] = strconv.FormatUint(uint64(v), 10)
+		}
+		resources[util.GenHash(entry.Name())] = model.HostResourceBase{
+			Name:       entry.Name(),
+			Path:       entry.Name(),
+			Attributes: attributes,
+		}
+	}
+	return resources, nil
+}
+
+// Identities derives identities from the devices of the interfaces and the port of multi-port devices, so interfaces
+// keep their IDs if they are named in a different order. Virtual interfaces have no identity.
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		itfPath := path.Join(h.sysfsPath, sysfsClassPath, base.Name)
+		identity, err := util.GetDeviceIdentity(path.Join(itfPath, "device"))
+		if err != nil {
+			continue
+		}
+		port, err := util.ReadSysfsAttr(path.Join(itfPath, "dev_port"))
+		if err != nil {
+			port = "0"
+		}
+		identities[id] = identity + ":" + port
+	}
+	return identities, nil
+}
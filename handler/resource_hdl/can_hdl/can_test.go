@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package can_hdl
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	sysfsPath := t.TempDir()
+	itfs := map[string]map[string]string{
+		"can0": {"type": "280\n", "ifindex": "3\n", "operstate": "up\n"},
+		"can1": {"type": "280\n", "ifindex": "4\n", "operstate": "down\n"},
+		"eth0": {"type": "1\n", "ifindex": "2\n", "operstate": "up\n"},
+	}
+	for name, attributes := range itfs {
+		p := path.Join(sysfsPath, sysfsClassPath, name)
+		if err := os.MkdirAll(p, 0775); err != nil {
+			t.Fatal(err)
+		}
+		for attr, value := range attributes {
+			if err := os.WriteFile(path.Join(p, attr), []byte(value), 0664); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	h := New(sysfsPath)
+	h.bitratesFunc = func() (map[string]uint32, error) {
+		return map[string]uint32{"can0": 500000}, nil
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("can0"): {
+			Name:       "can0",
+			Path:       "can0",
+			Attributes: map[string]string{"ifindex": "3", "state": "up", "bitrate": "500000"},
+		},
+		util.GenHash("can1"): {
+			Name:       "can1",
+			Path:       "can1",
+			Attributes: map[string]string{"ifindex": "4", "state": "down"},
+		},
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
+
+func TestParseAttrs(t *testing.T) {
+	var b []byte
+	b = appendAttr(b, iflaInfoKind, []byte("can\x00"))
+	b = appendAttr(b, iflaInfoData, appendAttr(nil, iflaCanBittiming, []byte{1, 2, 3, 4, 5}))
+	attrs := parseAttrs(b)
+	if string(attrs[iflaInfoKind]) != "can\x00" {
+		t.Errorf("got %q, expected %q", attrs[iflaInfoKind], "can\x00")
+	}
+	a := []byte{1, 2, 3, 4, 5}
+	if c := parseAttrs(attrs[iflaInfoData])[iflaCanBittiming]; !reflect.DeepEqual(a, c) {
+		t.Errorf("got %+v, expected %+v", c, a)
+	}
+}
+
+func appendAttr(b []byte, t uint16, v []byte) []byte {
+	l := 4 + len(v)
+	b = binary.NativeEndian.AppendUint16(b, uint16(l))
+	b = binary.NativeEndian.AppendUint16(b, t)
+	b = append(b, v...)
+	for l%4 != 0 {
+		b = append(b, 0)
+		l++
+	}
+	return b
+}
+
+func TestHandler_Identities(t *testing.T) {
+	sysfsPath := t.TempDir()
+	usbPath := path.Join(sysfsPath, "devices/platform/usb1/1-1")
+	for attr, value := range map[string]string{"idVendor": "1d50\n", "idProduct": "606f\n", "serial": "123\n"} {
+		if err := os.MkdirAll(path.Join(usbPath, "1-1:1.0"), 0775); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(usbPath, attr), []byte(value), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, port := range map[string]string{"can0": "0\n", "can1": "1\n", "vcan0": ""} {
+		p := path.Join(sysfsPath, sysfsClassPath, name)
+		if err := os.MkdirAll(p, 0775); err != nil {
+			t.Fatal(err)
+		}
+		if port == "" {
+			continue
+		}
+		if err := os.Symlink(path.Join(usbPath, "1-1:1.0"), path.Join(p, "device")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(p, "dev_port"), []byte(port), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resources := map[string]model.HostResourceBase{
+		"a": {Name: "can0", Path: "can0"},
+		"b": {Name: "can1", Path: "can1"},
+		"c": {Name: "vcan0", Path: "vcan0"},
+	}
+	identities, err := New(sysfsPath).Identities(context.Background(), resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]string{"a": "usb:1d50:606f:123:0", "b": "usb:1d50:606f:123:1"}
+	if !reflect.DeepEqual(a, identities) {
+		t.Errorf("got %v, expected %v", identities, a)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package can_hdl
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The bitrate of a CAN interface is not exposed via sysfs and must be read from the link info via netlink.
+
+const (
+	iflaLinkInfo     = 18
+	iflaInfoKind     = 1
+	iflaInfoData     = 2
+	iflaCanBittiming = 1
+	nlaTypeMask      = 0x3fff
+	rtmgrpLink       = 0x1
+)
+
+func getBitrates() (map[string]uint32, error) {
+	b, err := syscall.NetlinkRIB(syscall.RTM_GETLINK, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	bitrates := make(map[string]uint32)
+	for _, msg := range msgs {
+		if msg.Header.Type != syscall.RTM_NEWLINK {
+			continue
+		}
+		attrs, err := syscall.ParseNetlinkRouteAttr(&msg)
+		if err != nil {
+			return nil, err
+		}
+		var name string
+		var linkInfo []byte
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case syscall.IFLA_IFNAME:
+				name = strings.TrimRight(string(attr.Value), "\x00")
+			case iflaLinkInfo:
+				linkInfo = attr.Value
+			}
+		}
+		if name == "" || linkInfo == nil {
+			continue
+		}
+		info := parseAttrs(linkInfo)
+		if strings.TrimRight(string(info[iflaInfoKind]), "\x00") != "can" {
+			continue
+		}
+		bt := parseAttrs(info[iflaInfoData])[iflaCanBittiming]
+		if len(bt) < 4 {
+			continue
+		}
+		bitrates[name] = binary.NativeEndian.Uint32(bt[:4])
+	}
+	return bitrates, nil
+}
+
+func parseAttrs(b []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for len(b) >= syscall.SizeofRtAttr {
+		l := int(binary.NativeEndian.Uint16(b[0:2]))
+		t := binary.NativeEndian.Uint16(b[2:4]) & nlaTypeMask
+		if l < syscall.SizeofRtAttr || l > len(b) {
+			break
+		}
+		attrs[t] = b[syscall.SizeofRtAttr:l]
+		l = (l + syscall.RTA_ALIGNTO - 1) &^ (syscall.RTA_ALIGNTO - 1)
+		if l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return attrs
+}
+
+// watchLinks calls f after links were added, removed or changed and blocks until ctx is done. Changes within the delay
+// are merged into a single call.
+func watchLinks(ctx context.Context, delay time.Duration, f func()) error {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC|syscall.SOCK_NONBLOCK, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return os.NewSyscallError("socket", err)
+	}
+	if err = syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: rtmgrpLink}); err != nil {
+		_ = syscall.Close(fd)
+		return os.NewSyscallError("bind", err)
+	}
+	file := os.NewFile(uintptr(fd), "netlink")
+	defer file.Close()
+	events := make(chan error)
+	go func() {
+		buf := make([]byte, os.Getpagesize())
+		for {
+			n, err := file.Read(buf)
+			if err == nil && !hasLinkMsg(buf[:n]) {
+				continue
+			}
+			select {
+			case events <- err:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-events:
+			if err != nil {
+				return err
+			}
+			timer.Reset(delay)
+		case <-timer.C:
+			f()
+		}
+	}
+}
+
+func hasLinkMsg(b []byte) bool {
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return false
+	}
+	for _, msg := range msgs {
+		if msg.Header.Type == syscall.RTM_NEWLINK || msg.Header.Type == syscall.RTM_DELLINK {
+			return true
+		}
+	}
+	return false
+}
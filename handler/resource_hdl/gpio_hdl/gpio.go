@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gpio_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	devPrefix      = "gpiochip"
+	sysfsClassPath = "class/gpio"
+	sysfsBusPath   = "bus/gpio/devices"
+)
+
+type Handler struct {
+	devPath   string
+	sysfsPath string
+}
+
+func New(devPath, sysfsPath string) *Handler {
+	return &Handler{
+		devPath:   devPath,
+		sysfsPath: sysfsPath,
+	}
+}
+
+// Watch calls onChange after GPIO chip device nodes were added or removed and blocks until ctx is done.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchMatch(ctx, h.devPath, func(name string) bool {
+		return strings.HasPrefix(name, devPrefix)
+	}, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	entries, err := fs.ReadDir(os.DirFS(h.devPath), ".")
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) && pathErr.Op == "open" {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	chipInfo := h.getChipInfo()
+	resources := make(map[string]model.HostResourceBase)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), devPrefix) {
+			continue
+		}
+		resources[util.GenHash(entry.Name())] = model.HostResourceBase{
+			Name:       entry.Name(),
+			Path:       path.Join(h.devPath, entry.Name()),
+			Attributes: chipInfo[entry.Name()],
+		}
+	}
+	return resources, nil
+}
+
+// getChipInfo reads label and line count from the sysfs gpio class. Class entries are named after the
+// chip's base number, so they are mapped to the character device via their parent device.
+func (h *Handler) getChipInfo() map[string]map[string]string {
+	classPath := path.Join(h.sysfsPath, sysfsClassPath)
+	entries, err := os.ReadDir(classPath)
+	if err != nil {
+		return nil
+	}
+	chipInfo := make(map[string]map[string]string)
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), devPrefix) {
+			continue
+		}
+		entryPath := path.Join(classPath, entry.Name())
+		devName, ok := getDevName(entryPath)
+		if !ok {
+			continue
+		}
+		attributes := make(map[string]string)
+		if v, err := util.ReadSysfsAttr(path.Join(entryPath, "label")); err == nil {
+			attributes["label"] = v
+		}
+		if v, err := util.ReadSysfsAttr(path.Join(entryPath, "ngpio")); err == nil {
+			attributes["lines"] = v
+		}
+		chipInfo[devName] = attributes
+	}
+	return chipInfo
+}
+
+func getDevName(entryPath string) (string, bool) {
+	parent, err := filepath.EvalSymlinks(path.Join(entryPath, "device"))
+	if err != nil {
+		return "", false
+	}
+	if name := path.Base(parent); strings.HasPrefix(name, devPrefix) {
+		return name, true
+	}
+	children, err := os.ReadDir(parent)
+	if err != nil {
+		return "", false
+	}
+	for _, child := range children {
+		if child.IsDir() && strings.HasPrefix(child.Name(), devPrefix) {
+			return child.Name(), true
+		}
+	}
+	return "", false
+}
+
+// Identities derives identities from the GPIO controllers of the chips, so chips keep their IDs if they are numbered
+// in a different order.
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		chipPath, err := filepath.EvalSymlinks(path.Join(h.sysfsPath, sysfsBusPath, path.Base(base.Path)))
+		if err != nil {
+			continue
+		}
+		identity, err := util.GetDeviceIdentity(path.Dir(chipPath))
+		if err != nil {
+			continue
+		}
+		identities[id] = identity + ":" + base.Attributes["label"]
+	}
+	return identities, nil
+}
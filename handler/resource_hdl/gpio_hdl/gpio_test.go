@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gpio_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	devPath := path.Join(t.TempDir(), "dev")
+	sysfsPath := path.Join(t.TempDir(), "sys")
+	h := New(devPath, sysfsPath)
+	t.Run("dev path does not exist", func(t *testing.T) {
+		res, err := h.Get(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		if len(res) != 0 {
+			t.Error("expected empty map")
+		}
+	})
+	if err := os.MkdirAll(devPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gpiochip0", "gpiochip1", "ttyS0"} {
+		if err := os.WriteFile(path.Join(devPath, name), nil, 0660); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// chip with parent device
+	mkSysfsDir(t, path.Join(sysfsPath, "devices/platform/soc/gpio/gpiochip0"), nil)
+	mkSysfsDir(t, path.Join(sysfsPath, "class/gpio/gpiochip512"), map[string]string{"label": "pinctrl-bcm2711\n", "ngpio": "58\n"})
+	if err := os.Symlink(path.Join(sysfsPath, "devices/platform/soc/gpio"), path.Join(sysfsPath, "class/gpio/gpiochip512/device")); err != nil {
+		t.Fatal(err)
+	}
+	// chip without parent device
+	mkSysfsDir(t, path.Join(sysfsPath, "devices/gpiochip1"), nil)
+	mkSysfsDir(t, path.Join(sysfsPath, "class/gpio/gpiochip570"), map[string]string{"label": "raspberrypi-exp-gpio\n", "ngpio": "8\n"})
+	if err := os.Symlink(path.Join(sysfsPath, "devices/gpiochip1"), path.Join(sysfsPath, "class/gpio/gpiochip570/device")); err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("gpiochip0"): {
+			Name:       "gpiochip0",
+			Path:       path.Join(devPath, "gpiochip0"),
+			Attributes: map[string]string{"label": "pinctrl-bcm2711", "lines": "58"},
+		},
+		util.GenHash("gpiochip1"): {
+			Name:       "gpiochip1",
+			Path:       path.Join(devPath, "gpiochip1"),
+			Attributes: map[string]string{"label": "raspberrypi-exp-gpio", "lines": "8"},
+		},
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
+
+func mkSysfsDir(t *testing.T, p string, attributes map[string]string) {
+	if err := os.MkdirAll(p, 0775); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range attributes {
+		if err := os.WriteFile(path.Join(p, name), []byte(value), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i2c_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	devPrefix      = "i2c-"
+	sysfsClassPath = "class/i2c-dev"
+)
+
+type Handler struct {
+	devPath   string
+	sysfsPath string
+}
+
+func New(devPath, sysfsPath string) *Handler {
+	return &Handler{
+		devPath:   devPath,
+		sysfsPath: sysfsPath,
+	}
+}
+
+// Watch calls onChange after I2C device nodes were added or removed and blocks until ctx is done.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchMatch(ctx, h.devPath, func(name string) bool {
+		return strings.HasPrefix(name, devPrefix)
+	}, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	entries, err := fs.ReadDir(os.DirFS(h.devPath), ".")
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) && pathErr.Op == "open" {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	resources := make(map[string]model.HostResourceBase)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), devPrefix) {
+			continue
+		}
+		attributes := map[string]string{
+			"bus": strings.TrimPrefix(entry.Name(), devPrefix),
+		}
+		if v, err := util.ReadSysfsAttr(path.Join(h.sysfsPath, sysfsClassPath, entry.Name(), "name")); err == nil {
+			attributes["adapter"] = v
+		}
+		resources[util.GenHash(entry.Name())] = model.HostResourceBase{
+			Name:       entry.Name(),
+			Path:       path.Join(h.devPath, entry.Name()),
+			Attributes: attributes,
+		}
+	}
+	return resources, nil
+}
+
+// Identities derives identities from the controllers of the adapters, so buses keep their IDs if they are numbered in
+// a different order.
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		adapterPath, err := filepath.EvalSymlinks(path.Join(h.sysfsPath, sysfsClassPath, path.Base(base.Path), "device"))
+		if err != nil {
+			continue
+		}
+		identity, err := util.GetDeviceIdentity(path.Dir(adapterPath))
+		if err != nil {
+			continue
+		}
+		identities[id] = identity
+	}
+	return identities, nil
+}
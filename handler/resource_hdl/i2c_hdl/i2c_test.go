@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i2c_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	devPath := t.TempDir()
+	sysfsPath := t.TempDir()
+	for _, name := range []string{"i2c-1", "i2c-20", "ttyS0"} {
+		if err := os.WriteFile(path.Join(devPath, name), nil, 0660); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(path.Join(sysfsPath, "class/i2c-dev/i2c-1"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(sysfsPath, "class/i2c-dev/i2c-1/name"), []byte("bcm2835 (i2c@7e804000)\n"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("i2c-1"): {
+			Name:       "i2c-1",
+			Path:       path.Join(devPath, "i2c-1"),
+			Attributes: map[string]string{"bus": "1", "adapter": "bcm2835 (i2c@7e804000)"},
+		},
+		util.GenHash("i2c-20"): {
+			Name:       "i2c-20",
+			Path:       path.Join(devPath, "i2c-20"),
+			Attributes: map[string]string{"bus": "20"},
+		},
+	}
+	b, err := New(devPath, sysfsPath).Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
+
+func TestHandler_Identities(t *testing.T) {
+	sysfsPath := t.TempDir()
+	adapterPath := path.Join(sysfsPath, "devices/platform/soc/fe804000.i2c/i2c-1")
+	if err := os.MkdirAll(adapterPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(sysfsPath, sysfsClassPath, "i2c-1"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(adapterPath, path.Join(sysfsPath, sysfsClassPath, "i2c-1", "device")); err != nil {
+		t.Fatal(err)
+	}
+	resources := map[string]model.HostResourceBase{
+		"a": {Name: "i2c-1", Path: "/dev/i2c-1"},
+		"b": {Name: "i2c-2", Path: "/dev/i2c-2"},
+	}
+	identities, err := New("/dev", sysfsPath).Identities(context.Background(), resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]string{"a": "dev:platform/soc/fe804000.i2c"}
+	if !reflect.DeepEqual(a, identities) {
+		t.Errorf("got %v, expected %v", identities, a)
+	}
+}
@@ -24,3 +24,25 @@ import (
 type ResHandler interface {
 	Get(ctx context.Context) (map[string]model.HostResourceBase, error)
 }
+
+// IdentityProvider is implemented by ResHandlers that can derive a hardware identity for their resources.
+// Resources without an identity are identified by their ID.
+type IdentityProvider interface {
+	Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error)
+}
+
+// Watcher is implemented by ResHandlers that detect changes of their resources. Watch calls onChange after changes
+// and blocks until ctx is done.
+type Watcher interface {
+	Watch(ctx context.Context, onChange func()) error
+}
+
+type AnnotationHandler interface {
+	List(ctx context.Context) (map[string]model.ResourceAnnotation, error)
+}
+
+type IdentityHandler interface {
+	StableIDs(ctx context.Context, rType model.ResourceType, identities map[string]string) (map[string]string, error)
+	Resolve(ctx context.Context, rID string) (string, error)
+	ListAliases(ctx context.Context) ([]model.ResourceAlias, error)
+}
@@ -21,59 +21,245 @@ import (
 	"errors"
 	"fmt"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Handler struct {
-	handlers map[model.ResourceType]ResHandler
+	handlers      map[model.ResourceType]ResHandler
+	annotationHdl AnnotationHandler
+	identityHdl   IdentityHandler
+	timeout       time.Duration
+	cacheTTL      time.Duration
+	cache         map[model.ResourceType]cacheEntry
+	mu            sync.RWMutex
 }
 
-func New(handlers map[model.ResourceType]ResHandler) *Handler {
+type cacheEntry struct {
+	resources []model.HostResource
+	timestamp time.Time
+}
+
+func New(handlers map[model.ResourceType]ResHandler, annotationHdl AnnotationHandler, identityHdl IdentityHandler, timeout, cacheTTL time.Duration) *Handler {
 	return &Handler{
-		handlers: handlers,
+		handlers:      handlers,
+		annotationHdl: annotationHdl,
+		identityHdl:   identityHdl,
+		timeout:       timeout,
+		cacheTTL:      cacheTTL,
+		cache:         make(map[model.ResourceType]cacheEntry),
 	}
 }
 
-func (h *Handler) List(ctx context.Context, filter model.HostResourceFilter) ([]model.HostResource, error) {
-	var resources []model.HostResource
-	for t, handler := range h.handlers {
-		res, err := handler.Get(ctx)
-		if err != nil {
-			return nil, err
-		}
-		for id, base := range res {
-			resources = append(resources, model.HostResource{
-				ID:               genID(t, id),
-				Type:             t,
-				HostResourceBase: base,
+func (h *Handler) List(ctx context.Context, filter model.HostResourceFilter) (model.HostResourceList, error) {
+	annotations, err := h.annotationHdl.List(ctx)
+	if err != nil {
+		return model.HostResourceList{}, err
+	}
+	type result struct {
+		t         model.ResourceType
+		resources []model.HostResource
+		err       error
+	}
+	ch := make(chan result, len(h.handlers))
+	for t := range h.handlers {
+		go func() {
+			res, err := h.getResources(ctx, t)
+			ch <- result{t: t, resources: res, err: err}
+		}()
+	}
+	var resourceList model.HostResourceList
+	for range h.handlers {
+		r := <-ch
+		if r.err != nil {
+			resourceList.Errors = append(resourceList.Errors, model.HostResourceListErr{
+				Type:  r.t,
+				Error: r.err.Error(),
 			})
+			continue
+		}
+		for _, resource := range r.resources {
+			if annotation, ok := annotations[resource.ID]; ok {
+				resource.HostResourceBase = applyAnnotation(resource.HostResourceBase, annotation)
+			}
+			if !hasTags(resource.Tags, filter.Tags) {
+				continue
+			}
+			resourceList.Resources = append(resourceList.Resources, resource)
 		}
 	}
-	return resources, nil
+	slices.SortFunc(resourceList.Errors, func(a, b model.HostResourceListErr) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	return resourceList, nil
 }
 
 func (h *Handler) Get(ctx context.Context, rID string) (model.HostResource, error) {
-	t, id, err := parseID(rID)
+	rID, err := h.ResolveID(ctx, rID)
+	if err != nil {
+		return model.HostResource{}, err
+	}
+	t, _, err := parseID(rID)
 	if err != nil {
 		return model.HostResource{}, model.NewInvalidInputError(err)
 	}
-	handler, ok := h.handlers[t]
-	if !ok {
+	if _, ok := h.handlers[t]; !ok {
 		return model.HostResource{}, model.NewInvalidInputError(fmt.Errorf("unknown resource type '%s'", t))
 	}
-	res, err := handler.Get(ctx)
+	resources, err := h.getResources(ctx, t)
 	if err != nil {
 		return model.HostResource{}, err
 	}
-	base, ok := res[id]
-	if !ok {
+	i := slices.IndexFunc(resources, func(resource model.HostResource) bool {
+		return resource.ID == rID
+	})
+	if i < 0 {
 		return model.HostResource{}, model.NewNotFoundError(fmt.Errorf("resource '%s' not found", rID))
 	}
-	return model.HostResource{
-		ID:               rID,
-		Type:             t,
-		HostResourceBase: base,
-	}, nil
+	resource := resources[i]
+	annotations, err := h.annotationHdl.List(ctx)
+	if err != nil {
+		return model.HostResource{}, err
+	}
+	if annotation, ok := annotations[rID]; ok {
+		resource.HostResourceBase = applyAnnotation(resource.HostResourceBase, annotation)
+	}
+	return resource, nil
+}
+
+// Invalidate removes the cached resources of the given types or of all types if none are given.
+func (h *Handler) Invalidate(types ...model.ResourceType) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(types) == 0 {
+		clear(h.cache)
+		return
+	}
+	for _, t := range types {
+		delete(h.cache, t)
+	}
+}
+
+// Watch invalidates the cached resources of a type after the type's handler reported changes. Only handlers
+// implementing Watcher are watched. Blocks until ctx is done.
+func (h *Handler) Watch(ctx context.Context) {
+	var wg sync.WaitGroup
+	for t, hdl := range h.handlers {
+		w, ok := hdl.(Watcher)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.Watch(ctx, func() {
+				h.Invalidate(t)
+			}); err != nil {
+				util.Logger.Errorf("watching %s resources failed: %s", t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// getResources returns the resources of a type from the cache or from the type's handler if no valid cache entry exists.
+func (h *Handler) getResources(ctx context.Context, t model.ResourceType) ([]model.HostResource, error) {
+	h.mu.RLock()
+	entry, ok := h.cache[t]
+	h.mu.RUnlock()
+	if ok && time.Since(entry.timestamp) < h.cacheTTL {
+		return entry.resources, nil
+	}
+	timestamp := time.Now()
+	resources, err := h.fetchResources(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if h.cacheTTL > 0 {
+		h.mu.Lock()
+		h.cache[t] = cacheEntry{resources: resources, timestamp: timestamp}
+		h.mu.Unlock()
+	}
+	return resources, nil
+}
+
+// fetchResources queries the handler of a type and assigns stable IDs. Handlers that ignore the context are
+// abandoned once the timeout expires.
+func (h *Handler) fetchResources(ctx context.Context, t model.ResourceType) ([]model.HostResource, error) {
+	ctxWt, cf := context.WithTimeout(ctx, h.timeout)
+	defer cf()
+	type result struct {
+		resources []model.HostResource
+		err       error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		handler := h.handlers[t]
+		res, err := handler.Get(ctxWt)
+		if err != nil {
+			ch <- result{err: err}
+			return
+		}
+		stableIDs, err := h.getStableIDs(ctxWt, t, handler, res)
+		if err != nil {
+			ch <- result{err: err}
+			return
+		}
+		resources := make([]model.HostResource, 0, len(res))
+		for id, base := range res {
+			resources = append(resources, model.HostResource{
+				ID:               stableIDs[id],
+				Type:             t,
+				HostResourceBase: base,
+			})
+		}
+		ch <- result{resources: resources}
+	}()
+	select {
+	case r := <-ch:
+		return r.resources, r.err
+	case <-ctxWt.Done():
+		return nil, model.NewInternalError(fmt.Errorf("getting '%s' resources failed: %s", t, ctxWt.Err()))
+	}
+}
+
+// ResolveID returns the stable ID for a resource ID that might be an alias.
+func (h *Handler) ResolveID(ctx context.Context, rID string) (string, error) {
+	return h.identityHdl.Resolve(ctx, rID)
+}
+
+func (h *Handler) ListAliases(ctx context.Context) ([]model.ResourceAlias, error) {
+	return h.identityHdl.ListAliases(ctx)
+}
+
+func (h *Handler) getStableIDs(ctx context.Context, t model.ResourceType, handler ResHandler, res map[string]model.HostResourceBase) (map[string]string, error) {
+	var hwIdentities map[string]string
+	if provider, ok := handler.(IdentityProvider); ok {
+		var err error
+		if hwIdentities, err = provider.Identities(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+	identities := make(map[string]string)
+	for id := range res {
+		if identity, ok := hwIdentities[id]; ok && identity != "" {
+			identities[genID(t, id)] = identity
+		} else {
+			identities[genID(t, id)] = "id:" + id
+		}
+	}
+	rIDs, err := h.identityHdl.StableIDs(ctx, t, identities)
+	if err != nil {
+		return nil, err
+	}
+	stableIDs := make(map[string]string)
+	for id := range res {
+		stableIDs[id] = rIDs[genID(t, id)]
+	}
+	return stableIDs, nil
 }
 
 func (h *Handler) Handlers() []string {
@@ -84,6 +270,32 @@ func (h *Handler) Handlers() []string {
 	return handlers
 }
 
+func applyAnnotation(base model.HostResourceBase, annotation model.ResourceAnnotation) model.HostResourceBase {
+	if annotation.Name != "" {
+		base.Name = annotation.Name
+	}
+	if len(annotation.Tags) > 0 {
+		tags := make([]string, 0, len(base.Tags)+len(annotation.Tags))
+		tags = append(tags, base.Tags...)
+		for _, tag := range annotation.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		base.Tags = tags
+	}
+	return base
+}
+
+func hasTags(tags, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
 func genID(t model.ResourceType, id string) string {
 	return t + ":" + id
 }
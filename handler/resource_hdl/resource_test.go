@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testResHandler struct {
+	resources map[string]model.HostResourceBase
+	err       error
+	delay     time.Duration
+	calls     atomic.Int32
+}
+
+func (h *testResHandler) Get(_ context.Context) (map[string]model.HostResourceBase, error) {
+	h.calls.Add(1)
+	time.Sleep(h.delay)
+	return h.resources, h.err
+}
+
+type testWatchHandler struct {
+	testResHandler
+	changes chan struct{}
+}
+
+func (h *testWatchHandler) Watch(ctx context.Context, onChange func()) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.changes:
+			onChange()
+		}
+	}
+}
+
+type testAnnotationHdl struct{}
+
+func (testAnnotationHdl) List(_ context.Context) (map[string]model.ResourceAnnotation, error) {
+	return nil, nil
+}
+
+type testIdentityHdl struct{}
+
+func (testIdentityHdl) StableIDs(_ context.Context, _ model.ResourceType, identities map[string]string) (map[string]string, error) {
+	ids := make(map[string]string)
+	for rID := range identities {
+		ids[rID] = rID
+	}
+	return ids, nil
+}
+
+func (testIdentityHdl) Resolve(_ context.Context, rID string) (string, error) {
+	return rID, nil
+}
+
+func (testIdentityHdl) ListAliases(_ context.Context) ([]model.ResourceAlias, error) {
+	return nil, nil
+}
+
+func TestHandler_List(t *testing.T) {
+	serialHdl := &testResHandler{resources: map[string]model.HostResourceBase{"a": {Name: "a"}}}
+	gpioHdl := &testResHandler{err: errors.New("test")}
+	videoHdl := &testResHandler{resources: map[string]model.HostResourceBase{"b": {Name: "b"}}, delay: time.Second}
+	h := New(map[model.ResourceType]ResHandler{
+		model.SerialDevice: serialHdl,
+		model.GPIOChip:     gpioHdl,
+		model.VideoDevice:  videoHdl,
+	}, testAnnotationHdl{}, testIdentityHdl{}, 100*time.Millisecond, time.Minute)
+	start := time.Now()
+	resourceList, err := h.List(context.Background(), model.HostResourceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) >= time.Second {
+		t.Error("expected timeout")
+	}
+	if len(resourceList.Resources) != 1 || resourceList.Resources[0].ID != "serial:a" {
+		t.Errorf("unexpected resources %+v", resourceList.Resources)
+	}
+	if len(resourceList.Errors) != 2 || resourceList.Errors[0].Type != model.GPIOChip || resourceList.Errors[1].Type != model.VideoDevice {
+		t.Errorf("unexpected errors %+v", resourceList.Errors)
+	}
+	t.Run("cache", func(t *testing.T) {
+		if _, err = h.List(context.Background(), model.HostResourceFilter{}); err != nil {
+			t.Fatal(err)
+		}
+		if c := serialHdl.calls.Load(); c != 1 {
+			t.Errorf("expected 1 call, got %d", c)
+		}
+		if c := gpioHdl.calls.Load(); c != 2 {
+			t.Errorf("expected errors not to be cached, got %d calls", c)
+		}
+		if _, err = h.Get(context.Background(), "serial:a"); err != nil {
+			t.Fatal(err)
+		}
+		if c := serialHdl.calls.Load(); c != 1 {
+			t.Errorf("expected 1 call, got %d", c)
+		}
+	})
+	t.Run("invalidate", func(t *testing.T) {
+		h.Invalidate(model.SerialDevice)
+		if _, err = h.Get(context.Background(), "serial:a"); err != nil {
+			t.Fatal(err)
+		}
+		if c := serialHdl.calls.Load(); c != 2 {
+			t.Errorf("expected 2 calls, got %d", c)
+		}
+	})
+}
+
+func TestHandler_Watch(t *testing.T) {
+	serialHdl := &testWatchHandler{testResHandler: testResHandler{resources: map[string]model.HostResourceBase{"a": {Name: "a"}}}, changes: make(chan struct{})}
+	gpioHdl := &testResHandler{resources: map[string]model.HostResourceBase{"b": {Name: "b"}}}
+	h := New(map[model.ResourceType]ResHandler{
+		model.SerialDevice: serialHdl,
+		model.GPIOChip:     gpioHdl,
+	}, testAnnotationHdl{}, testIdentityHdl{}, time.Second, time.Minute)
+	ctx, cf := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Watch(ctx)
+	}()
+	if _, err := h.List(context.Background(), model.HostResourceFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	serialHdl.changes <- struct{}{}
+	serialHdl.changes <- struct{}{}
+	if _, err := h.List(context.Background(), model.HostResourceFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if c := serialHdl.calls.Load(); c != 2 {
+		t.Errorf("expected 2 calls, got %d", c)
+	}
+	if c := gpioHdl.calls.Load(); c != 1 {
+		t.Errorf("expected 1 call, got %d", c)
+	}
+	cf()
+	<-done
+}
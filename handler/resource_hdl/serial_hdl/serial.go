@@ -21,20 +21,32 @@ import (
 	"errors"
 	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
 	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"time"
 )
 
 type Handler struct {
-	path string
+	path      string
+	sysfsPath string
 }
 
-func New(path string) *Handler {
+func New(path, sysfsPath string) *Handler {
 	return &Handler{
-		path: path,
+		path:      path,
+		sysfsPath: sysfsPath,
 	}
 }
 
+// Watch calls onChange after serial devices were added or removed and blocks until ctx is done. The directory is
+// watched again once it appears if it does not exist or is removed.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchRetry(ctx, h.path, nil, time.Second, onChange)
+}
+
 func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
 	dir := os.DirFS(h.path)
 	entries, err := fs.ReadDir(dir, ".")
@@ -60,3 +72,22 @@ func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, e
 	}
 	return resources, nil
 }
+
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		devPath, err := filepath.EvalSymlinks(base.Path)
+		if err != nil {
+			continue
+		}
+		identity, err := util.GetUSBIdentity(path.Join(h.sysfsPath, "class/tty", path.Base(devPath), "device"))
+		if err != nil {
+			continue
+		}
+		identities[id] = identity
+	}
+	return identities, nil
+}
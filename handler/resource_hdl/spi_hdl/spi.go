@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spi_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	devPrefix      = "spidev"
+	sysfsClassPath = "class/spidev"
+)
+
+type Handler struct {
+	devPath   string
+	sysfsPath string
+}
+
+func New(devPath, sysfsPath string) *Handler {
+	return &Handler{
+		devPath:   devPath,
+		sysfsPath: sysfsPath,
+	}
+}
+
+// Watch calls onChange after SPI device nodes were added or removed and blocks until ctx is done.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchMatch(ctx, h.devPath, func(name string) bool {
+		return strings.HasPrefix(name, devPrefix)
+	}, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	entries, err := fs.ReadDir(os.DirFS(h.devPath), ".")
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) && pathErr.Op == "open" {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	resources := make(map[string]model.HostResourceBase)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), devPrefix) {
+			continue
+		}
+		bus, cs, ok := strings.Cut(strings.TrimPrefix(entry.Name(), devPrefix), ".")
+		if !ok {
+			continue
+		}
+		resources[util.GenHash(entry.Name())] = model.HostResourceBase{
+			Name: entry.Name(),
+			Path: path.Join(h.devPath, entry.Name()),
+			Attributes: map[string]string{
+				"bus":         bus,
+				"chip_select": cs,
+			},
+		}
+	}
+	return resources, nil
+}
+
+// Identities derives identities from the SPI controllers and chip selects of the devices, so devices keep their IDs
+// if buses are numbered in a different order.
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		// the device is located below the controller at 'spi_master/spi<bus>/spi<bus>.<chip select>'
+		ctrlPath, err := filepath.EvalSymlinks(path.Join(h.sysfsPath, sysfsClassPath, path.Base(base.Path), "device"))
+		if err != nil {
+			continue
+		}
+		for strings.HasPrefix(path.Base(ctrlPath), "spi") {
+			ctrlPath = path.Dir(ctrlPath)
+		}
+		identity, err := util.GetDeviceIdentity(ctrlPath)
+		if err != nil {
+			continue
+		}
+		identities[id] = identity + ":" + base.Attributes["chip_select"]
+	}
+	return identities, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spi_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	devPath := t.TempDir()
+	for _, name := range []string{"spidev0.0", "spidev0.1", "spidev1", "ttyS0"} {
+		if err := os.WriteFile(path.Join(devPath, name), nil, 0660); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(path.Join(devPath, "spidev2.0"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("spidev0.0"): {
+			Name:       "spidev0.0",
+			Path:       path.Join(devPath, "spidev0.0"),
+			Attributes: map[string]string{"bus": "0", "chip_select": "0"},
+		},
+		util.GenHash("spidev0.1"): {
+			Name:       "spidev0.1",
+			Path:       path.Join(devPath, "spidev0.1"),
+			Attributes: map[string]string{"bus": "0", "chip_select": "1"},
+		},
+	}
+	b, err := New(devPath, t.TempDir()).Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+	t.Run("missing dev path", func(t *testing.T) {
+		b, err := New(path.Join(devPath, "missing"), t.TempDir()).Get(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		if len(b) != 0 {
+			t.Errorf("expected no resources, got %+v", b)
+		}
+	})
+}
+
+func TestHandler_Identities(t *testing.T) {
+	sysfsPath := t.TempDir()
+	devPath := path.Join(sysfsPath, "devices/platform/soc/fe204000.spi/spi_master/spi0/spi0.1")
+	if err := os.MkdirAll(devPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(sysfsPath, sysfsClassPath, "spidev0.1"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(devPath, path.Join(sysfsPath, sysfsClassPath, "spidev0.1", "device")); err != nil {
+		t.Fatal(err)
+	}
+	resources := map[string]model.HostResourceBase{
+		"a": {Name: "spidev0.1", Path: "/dev/spidev0.1", Attributes: map[string]string{"bus": "0", "chip_select": "1"}},
+		"b": {Name: "spidev1.0", Path: "/dev/spidev1.0", Attributes: map[string]string{"bus": "1", "chip_select": "0"}},
+	}
+	identities, err := New("/dev", sysfsPath).Identities(context.Background(), resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := map[string]string{"a": "dev:platform/soc/fe204000.spi:1"}
+	if !reflect.DeepEqual(a, identities) {
+		t.Errorf("got %v, expected %v", identities, a)
+	}
+}
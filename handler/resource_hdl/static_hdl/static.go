@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package static_hdl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const fileExt = ".json"
+
+type definition struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Path       string            `json:"path"`
+	Tags       []string          `json:"tags"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type Handler struct {
+	dirPath   string
+	resources map[string]model.HostResourceBase
+	status    model.StaticResourcesStatus
+	mu        sync.RWMutex
+}
+
+func New(dirPath string) (*Handler, error) {
+	if !path.IsAbs(dirPath) {
+		return nil, fmt.Errorf("path '%s' not absolute", dirPath)
+	}
+	return &Handler{
+		dirPath:   dirPath,
+		resources: make(map[string]model.HostResourceBase),
+		status:    model.StaticResourcesStatus{Path: dirPath},
+	}, nil
+}
+
+// Load reads all resource definitions from the directory. Invalid files are skipped and reported via the status.
+func (h *Handler) Load() {
+	resources := make(map[string]model.HostResourceBase)
+	status := model.StaticResourcesStatus{
+		Path:   h.dirPath,
+		Loaded: time.Now().UTC(),
+	}
+	entries, err := os.ReadDir(h.dirPath)
+	if err != nil {
+		status.Error = err.Error()
+		util.Logger.Warningf("loading static resources failed: %s", err)
+	}
+	paths := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExt) {
+			continue
+		}
+		file := model.StaticResourceFile{Name: entry.Name()}
+		res, err := readFile(path.Join(h.dirPath, entry.Name()))
+		if err == nil {
+			err = checkDuplicates(res, resources, paths)
+		}
+		if err != nil {
+			file.Error = err.Error()
+			util.Logger.Warningf("invalid static resources file '%s': %s", entry.Name(), err)
+		} else {
+			for id, base := range res {
+				resources[id] = base
+				paths[base.Path] = entry.Name()
+			}
+			file.Resources = len(res)
+		}
+		status.Files = append(status.Files, file)
+	}
+	h.mu.Lock()
+	h.resources = resources
+	h.status = status
+	h.mu.Unlock()
+}
+
+// Watch reloads the resource definitions on changes to the directory until ctx is done.
+// The optional onLoad function is called after each reload. If the directory does not exist or is removed, watching is
+// retried with an increasing delay and the definitions are loaded once the directory appears.
+func (h *Handler) Watch(ctx context.Context, onLoad func()) error {
+	reload := func() {
+		h.Load()
+		if onLoad != nil {
+			onLoad()
+		}
+	}
+	return dir_watcher.WatchRetry(ctx, h.dirPath, nil, time.Second, reload)
+}
+
+func (h *Handler) Get(_ context.Context) (map[string]model.HostResourceBase, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	resources := make(map[string]model.HostResourceBase)
+	for id, base := range h.resources {
+		resources[id] = base
+	}
+	return resources, nil
+}
+
+func (h *Handler) Status(_ context.Context) (model.StaticResourcesStatus, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	status := h.status
+	status.Files = append([]model.StaticResourceFile(nil), h.status.Files...)
+	return status, nil
+}
+
+func readFile(p string) (map[string]model.HostResourceBase, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	var definitions []definition
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '[' {
+		err = json.Unmarshal(b, &definitions)
+	} else {
+		var d definition
+		err = json.Unmarshal(b, &d)
+		definitions = append(definitions, d)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]model.HostResourceBase)
+	paths := make(map[string]struct{})
+	for i, d := range definitions {
+		if err = validate(d); err != nil {
+			return nil, fmt.Errorf("definition %d: %s", i, err)
+		}
+		id := util.GenHash(d.Name)
+		if _, ok := resources[id]; ok {
+			return nil, fmt.Errorf("definition %d: duplicate name '%s'", i, d.Name)
+		}
+		if _, ok := paths[d.Path]; ok {
+			return nil, fmt.Errorf("definition %d: duplicate path '%s'", i, d.Path)
+		}
+		paths[d.Path] = struct{}{}
+		attributes := make(map[string]string)
+		for k, v := range d.Attributes {
+			attributes[k] = v
+		}
+		if d.Type != "" {
+			attributes["type"] = d.Type
+		}
+		resources[id] = model.HostResourceBase{
+			Name:       d.Name,
+			Tags:       d.Tags,
+			Path:       d.Path,
+			Attributes: attributes,
+		}
+	}
+	return resources, nil
+}
+
+func validate(d definition) error {
+	if d.Name == "" {
+		return errors.New("missing name")
+	}
+	if !path.IsAbs(d.Path) {
+		return fmt.Errorf("path '%s' not absolute", d.Path)
+	}
+	for _, tag := range d.Tags {
+		if tag == "" {
+			return errors.New("empty tag")
+		}
+	}
+	return nil
+}
+
+func checkDuplicates(res, resources map[string]model.HostResourceBase, paths map[string]string) error {
+	for id, base := range res {
+		if _, ok := resources[id]; ok {
+			return fmt.Errorf("duplicate name '%s'", base.Name)
+		}
+		if file, ok := paths[base.Path]; ok {
+			return fmt.Errorf("duplicate path '%s' defined in '%s'", base.Path, file)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package static_hdl
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/y-du/go-log-level"
+	"github.com/y-du/go-log-level/level"
+	"io"
+	"log"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHandler_Load(t *testing.T) {
+	util.Logger, _ = log_level.New(log.New(io.Discard, "", 0), level.Off)
+	dirPath := path.Join(t.TempDir(), "static")
+	h, err := New(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("dir does not exist", func(t *testing.T) {
+		h.Load()
+		status, err := h.Status(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		if status.Error == "" {
+			t.Error("expected error")
+		}
+	})
+	if err = os.MkdirAll(dirPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"a.json":      `{"name": "modbus-bridge", "type": "modbus-tcp", "path": "/run/modbus.sock", "tags": ["bus:modbus"], "attributes": {"unit": "1"}}`,
+		"b.json":      `[{"name": "vendor-dev", "path": "/dev/vendor0"}, {"name": "vendor-dev-2", "path": "/dev/vendor1"}]`,
+		"c.json":      `{"name": "relative", "path": "dev/vendor2"}`,
+		"d.json":      `{"name": "modbus-bridge", "path": "/run/other.sock"}`,
+		"e.json":      `{"name": "other", "path": "/dev/vendor0"}`,
+		"f.json":      `{"name": `,
+		"ignored.txt": `{"name": "ignored", "path": "/dev/ignored"}`,
+	}
+	for name, content := range files {
+		if err = os.WriteFile(path.Join(dirPath, name), []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.Load()
+	a := map[string]model.HostResourceBase{
+		util.GenHash("modbus-bridge"): {
+			Name:       "modbus-bridge",
+			Tags:       []string{"bus:modbus"},
+			Path:       "/run/modbus.sock",
+			Attributes: map[string]string{"type": "modbus-tcp", "unit": "1"},
+		},
+		util.GenHash("vendor-dev"): {
+			Name:       "vendor-dev",
+			Path:       "/dev/vendor0",
+			Attributes: map[string]string{},
+		},
+		util.GenHash("vendor-dev-2"): {
+			Name:       "vendor-dev-2",
+			Path:       "/dev/vendor1",
+			Attributes: map[string]string{},
+		},
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+	status, err := h.Status(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if status.Error != "" {
+		t.Error(status.Error)
+	}
+	if len(status.Files) != 6 {
+		t.Fatalf("got %d files, expected %d", len(status.Files), 6)
+	}
+	for _, file := range status.Files {
+		switch file.Name {
+		case "a.json", "b.json":
+			if file.Error != "" {
+				t.Errorf("unexpected error for %s: %s", file.Name, file.Error)
+			}
+		default:
+			if file.Error == "" {
+				t.Errorf("expected error for %s", file.Name)
+			}
+		}
+	}
+}
+
+func TestHandler_Watch(t *testing.T) {
+	util.Logger, _ = log_level.New(log.New(io.Discard, "", 0), level.Off)
+	dirPath := t.TempDir()
+	h, err := New(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Load()
+	ctx, cf := context.WithCancel(context.Background())
+	defer cf()
+	go func() {
+		_ = h.Watch(ctx, nil)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err = os.WriteFile(path.Join(dirPath, "a.json"), []byte(`{"name": "test", "path": "/dev/test"}`), 0664); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	res, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := res[util.GenHash("test")]; !ok {
+		t.Error("expected reloaded resource")
+	}
+}
+
+func TestHandler_WatchMissingDir(t *testing.T) {
+	util.Logger, _ = log_level.New(log.New(io.Discard, "", 0), level.Off)
+	dirPath := path.Join(t.TempDir(), "static")
+	h, err := New(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Load()
+	ctx, cf := context.WithCancel(context.Background())
+	defer cf()
+	done := make(chan error)
+	go func() {
+		done <- h.Watch(ctx, nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err = os.Mkdir(dirPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path.Join(dirPath, "a.json"), []byte(`{"name": "a", "path": "/dev/a"}`), 0664); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	res, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := res[util.GenHash("a")]; !ok {
+		t.Error("expected resource loaded after directory appeared")
+	}
+	if err = os.WriteFile(path.Join(dirPath, "b.json"), []byte(`{"name": "b", "path": "/dev/b"}`), 0664); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	res, err = h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := res[util.GenHash("b")]; !ok {
+		t.Error("expected reloaded resource")
+	}
+	cf()
+	if err = <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandler_WatchRecreatedDir(t *testing.T) {
+	util.Logger, _ = log_level.New(log.New(io.Discard, "", 0), level.Off)
+	dirPath := path.Join(t.TempDir(), "static")
+	if err := os.Mkdir(dirPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Load()
+	ctx, cf := context.WithCancel(context.Background())
+	defer cf()
+	done := make(chan error)
+	go func() {
+		done <- h.Watch(ctx, nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err = os.Remove(dirPath); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err = os.Mkdir(dirPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if err = os.WriteFile(path.Join(dirPath, "a.json"), []byte(`{"name": "a", "path": "/dev/a"}`), 0664); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	res, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := res[util.GenHash("a")]; !ok {
+		t.Error("expected resource loaded after directory was recreated")
+	}
+	cf()
+	if err = <-done; err != nil {
+		t.Error(err)
+	}
+}
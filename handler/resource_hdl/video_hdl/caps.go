@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package video_hdl
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// The capabilities of a video device are not exposed via sysfs and must be queried from the device node. Opening the
+// node can wake up the device, so capabilities are only queried if enabled.
+
+const (
+	vidiocQueryCap        = 0x80685600 // _IOR('V', 0, struct v4l2_capability)
+	capVideoCapture       = 0x00000001
+	capVideoOutput        = 0x00000002
+	capVideoCaptureMPlane = 0x00001000
+	capVideoOutputMPlane  = 0x00002000
+	capMetaCapture        = 0x00800000
+	capDeviceCaps         = 0x80000000
+)
+
+type v4l2Capability struct {
+	driver       [16]uint8
+	card         [32]uint8
+	busInfo      [32]uint8
+	version      uint32
+	capabilities uint32
+	deviceCaps   uint32
+	reserved     [3]uint32
+}
+
+func queryCaps(p string) (uint32, error) {
+	file, err := os.OpenFile(p, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	var c v4l2Capability
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), vidiocQueryCap, uintptr(unsafe.Pointer(&c))); errno != 0 {
+		return 0, errno
+	}
+	if c.capabilities&capDeviceCaps != 0 {
+		return c.deviceCaps, nil
+	}
+	return c.capabilities, nil
+}
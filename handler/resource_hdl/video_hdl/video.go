@@ -0,0 +1,175 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package video_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"github.com/SENERGY-Platform/mgw-host-manager/util/dir_watcher"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	devPrefix      = "video"
+	sysfsClassPath = "class/video4linux"
+)
+
+type Handler struct {
+	devPath   string
+	sysfsPath string
+	capsFunc  func(p string) (uint32, error)
+	withCaps  bool
+	capsCache map[string]capsEntry
+	mu        sync.Mutex
+}
+
+type capsEntry struct {
+	info os.FileInfo
+	caps uint32
+}
+
+func New(devPath, sysfsPath string) *Handler {
+	return &Handler{
+		devPath:   devPath,
+		sysfsPath: sysfsPath,
+		capsFunc:  queryCaps,
+		capsCache: make(map[string]capsEntry),
+	}
+}
+
+// SetQueryCaps enables capture, output and metadata attributes. The capabilities must be queried from the device
+// nodes, so each device is opened once and its capabilities are cached until the device node is replaced.
+func (h *Handler) SetQueryCaps(b bool) {
+	h.withCaps = b
+}
+
+// Watch calls onChange after video device nodes were added or removed and blocks until ctx is done.
+func (h *Handler) Watch(ctx context.Context, onChange func()) error {
+	return dir_watcher.WatchMatch(ctx, h.devPath, func(name string) bool {
+		return strings.HasPrefix(name, devPrefix)
+	}, time.Second, onChange)
+}
+
+func (h *Handler) Get(ctx context.Context) (map[string]model.HostResourceBase, error) {
+	entries, err := fs.ReadDir(os.DirFS(h.devPath), ".")
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) && pathErr.Op == "open" {
+			return nil, nil
+		}
+		return nil, model.NewInternalError(err)
+	}
+	resources := make(map[string]model.HostResourceBase)
+	devPaths := make(map[string]struct{})
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), devPrefix) {
+			continue
+		}
+		devPath := path.Join(h.devPath, entry.Name())
+		devPaths[devPath] = struct{}{}
+		attributes := make(map[string]string)
+		name := entry.Name()
+		if v, err := util.ReadSysfsAttr(path.Join(h.sysfsPath, sysfsClassPath, entry.Name(), "name")); err == nil {
+			name = v
+		}
+		if v, err := util.ReadSysfsAttr(path.Join(h.sysfsPath, sysfsClassPath, entry.Name(), "index")); err == nil {
+			attributes["index"] = v
+		}
+		if caps, ok := h.getCaps(devPath); ok {
+			attributes["capture"] = boolStr(caps&(capVideoCapture|capVideoCaptureMPlane) != 0)
+			attributes["output"] = boolStr(caps&(capVideoOutput|capVideoOutputMPlane) != 0)
+			attributes["metadata"] = boolStr(caps&capMetaCapture != 0)
+		}
+		resources[util.GenHash(entry.Name())] = model.HostResourceBase{
+			Name:       name,
+			Path:       devPath,
+			Attributes: attributes,
+		}
+	}
+	h.pruneCaps(devPaths)
+	return resources, nil
+}
+
+func (h *Handler) Identities(ctx context.Context, resources map[string]model.HostResourceBase) (map[string]string, error) {
+	identities := make(map[string]string)
+	for id, base := range resources {
+		if ctx.Err() != nil {
+			return nil, model.NewInternalError(ctx.Err())
+		}
+		identity, err := util.GetUSBIdentity(path.Join(h.sysfsPath, sysfsClassPath, path.Base(base.Path), "device"))
+		if err != nil {
+			continue
+		}
+		idx := base.Attributes["index"]
+		if idx == "" {
+			idx = "0"
+		}
+		identities[id] = identity + ":" + idx
+	}
+	return identities, nil
+}
+
+// getCaps returns the cached capabilities of a device or queries them if the device node changed. Returns false if
+// querying capabilities is disabled or failed.
+func (h *Handler) getCaps(p string) (uint32, bool) {
+	if !h.withCaps {
+		return 0, false
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return 0, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry, ok := h.capsCache[p]; ok && os.SameFile(entry.info, info) {
+		return entry.caps, true
+	}
+	caps, err := h.capsFunc(p)
+	if err != nil {
+		return 0, false
+	}
+	h.capsCache[p] = capsEntry{info: info, caps: caps}
+	return caps, true
+}
+
+// pruneCaps removes cached capabilities of devices not contained in paths.
+func (h *Handler) pruneCaps(paths map[string]struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for p := range h.capsCache {
+		if _, ok := paths[p]; !ok {
+			delete(h.capsCache, p)
+		}
+	}
+}
+
+func boolStr(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
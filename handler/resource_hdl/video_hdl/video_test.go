@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package video_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/SENERGY-Platform/mgw-host-manager/util"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	devPath := t.TempDir()
+	sysfsPath := t.TempDir()
+	for _, name := range []string{"video0", "video1", "ttyS0"} {
+		if err := os.WriteFile(path.Join(devPath, name), nil, 0660); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(path.Join(sysfsPath, "class/video4linux/video0"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(sysfsPath, "class/video4linux/video0/name"), []byte("USB Camera: USB Camera\n"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(sysfsPath, "class/video4linux/video0/index"), []byte("0\n"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	h := New(devPath, sysfsPath)
+	h.SetQueryCaps(true)
+	h.capsFunc = func(p string) (uint32, error) {
+		if p == path.Join(devPath, "video0") {
+			return capVideoCapture, nil
+		}
+		return 0, errors.New("test error")
+	}
+	a := map[string]model.HostResourceBase{
+		util.GenHash("video0"): {
+			Name:       "USB Camera: USB Camera",
+			Path:       path.Join(devPath, "video0"),
+			Attributes: map[string]string{"index": "0", "capture": "true", "output": "false", "metadata": "false"},
+		},
+		util.GenHash("video1"): {
+			Name:       "video1",
+			Path:       path.Join(devPath, "video1"),
+			Attributes: map[string]string{},
+		},
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("got %+v, expected %+v", b, a)
+	}
+}
+
+func TestHandler_GetCaps(t *testing.T) {
+	devPath := t.TempDir()
+	if err := os.WriteFile(path.Join(devPath, "video0"), nil, 0660); err != nil {
+		t.Fatal(err)
+	}
+	h := New(devPath, t.TempDir())
+	count := 0
+	h.capsFunc = func(p string) (uint32, error) {
+		count++
+		return capVideoCapture, nil
+	}
+	b, err := h.Get(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := b[util.GenHash("video0")].Attributes["capture"]; ok || count != 0 {
+		t.Error("capabilities queried while disabled")
+	}
+	h.SetQueryCaps(true)
+	for i := 0; i < 2; i++ {
+		b, err = h.Get(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		if b[util.GenHash("video0")].Attributes["capture"] != "true" {
+			t.Errorf("got %+v", b)
+		}
+	}
+	if count != 1 {
+		t.Errorf("capabilities queried %d times, expected 1", count)
+	}
+	if err = os.Remove(path.Join(devPath, "video0")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = h.Get(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if len(h.capsCache) != 0 {
+		t.Error("cache not pruned")
+	}
+}
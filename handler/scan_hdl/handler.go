@@ -0,0 +1,420 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scan_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"github.com/google/uuid"
+	"net"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Handler struct {
+	netInfoHdl NetInfoHandler
+	dialer     net.Dialer
+	limiter    *time.Ticker
+	sem        chan struct{}
+	maxHosts   int
+	maxPorts   int
+	maxJobs    int
+	jobs       map[string]*job
+	wg         sync.WaitGroup
+	stopped    bool
+	mu         sync.RWMutex
+}
+
+type job struct {
+	model.NetScanJob
+	openPorts map[netip.Addr][]int
+	cf        context.CancelFunc
+}
+
+// New creates a handler that limits connection attempts of all jobs to rate per second and concurrency parallel
+// attempts. Each attempt is aborted after timeout. No more than maxJobs jobs can run at the same time.
+func New(netInfoHdl NetInfoHandler, rate, concurrency int, timeout time.Duration, maxHosts, maxPorts, maxJobs int) (*Handler, error) {
+	if rate <= 0 || time.Second/time.Duration(rate) <= 0 {
+		return nil, fmt.Errorf("invalid rate %d", rate)
+	}
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("invalid concurrency %d", concurrency)
+	}
+	if maxJobs <= 0 {
+		return nil, fmt.Errorf("invalid maximum of jobs %d", maxJobs)
+	}
+	return &Handler{
+		netInfoHdl: netInfoHdl,
+		dialer:     net.Dialer{Timeout: timeout},
+		limiter:    time.NewTicker(time.Second / time.Duration(rate)),
+		sem:        make(chan struct{}, concurrency),
+		maxHosts:   maxHosts,
+		maxPorts:   maxPorts,
+		maxJobs:    maxJobs,
+		jobs:       make(map[string]*job),
+	}, nil
+}
+
+// Run removes jobs that finished more than ttl ago after every tick. Running jobs are canceled once ctx is done.
+func (h *Handler) Run(ctx context.Context, tick, ttl time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			h.shutdown()
+			return
+		case <-ticker.C:
+			h.purge(ttl)
+		}
+	}
+}
+
+// List returns the jobs of the owner.
+func (h *Handler) List(_ context.Context, owner string) ([]model.NetScanJob, error) {
+	if owner == "" {
+		return nil, model.NewInvalidInputError(errors.New("missing owner"))
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	jobs := make([]model.NetScanJob, 0)
+	for _, j := range h.jobs {
+		if j.Owner != owner {
+			continue
+		}
+		jobs = append(jobs, j.snapshot())
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Created.Equal(jobs[j].Created) {
+			return jobs[i].ID < jobs[j].ID
+		}
+		return jobs[i].Created.Before(jobs[j].Created)
+	})
+	return jobs, nil
+}
+
+func (h *Handler) Get(_ context.Context, id, owner string) (model.NetScanJob, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	j, err := h.getJob(id, owner)
+	if err != nil {
+		return model.NetScanJob{}, err
+	}
+	return j.snapshot(), nil
+}
+
+// Add starts a TCP connect scan of all hosts of the requested network and returns the job ID. The network must belong
+// to an interface not hidden by the blacklists. Hosts in blacklisted ranges are skipped.
+func (h *Handler) Add(ctx context.Context, req model.NetScanRequest) (string, error) {
+	if req.Owner == "" {
+		return "", model.NewInvalidInputError(errors.New("missing owner"))
+	}
+	prefix, err := netip.ParsePrefix(req.CIDR)
+	if err != nil {
+		return "", model.NewInvalidInputError(err)
+	}
+	prefix = prefix.Masked()
+	if !prefix.Addr().Is4() {
+		return "", model.NewInvalidInputError(fmt.Errorf("network '%s' is not an IPv4 network", req.CIDR))
+	}
+	if n := uint64(1) << (32 - prefix.Bits()); n > uint64(h.maxHosts) {
+		return "", model.NewInvalidInputError(fmt.Errorf("network '%s' exceeds maximum of %d hosts", req.CIDR, h.maxHosts))
+	}
+	ports, err := genPorts(req.Ports, h.maxPorts)
+	if err != nil {
+		return "", model.NewInvalidInputError(err)
+	}
+	if err = h.checkNetwork(ctx, prefix); err != nil {
+		return "", err
+	}
+	idObj, err := uuid.NewUUID()
+	if err != nil {
+		return "", model.NewInternalError(err)
+	}
+	hosts, err := h.getHosts(ctx, prefix)
+	if err != nil {
+		return "", err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return "", model.NewInternalError(errors.New("scan handler stopped"))
+	}
+	if h.runningJobs() >= h.maxJobs {
+		return "", model.NewConflictError(fmt.Errorf("maximum of %d running scan jobs reached", h.maxJobs))
+	}
+	jCtx, cf := context.WithCancel(context.Background())
+	j := &job{
+		NetScanJob: model.NetScanJob{
+			ID:      idObj.String(),
+			State:   model.NetScanJobRunning,
+			Created: time.Now(),
+			Total:   len(hosts) * len(ports),
+			NetScanRequest: model.NetScanRequest{
+				CIDR:  prefix.String(),
+				Ports: ports,
+				Owner: req.Owner,
+			},
+		},
+		openPorts: make(map[netip.Addr][]int),
+		cf:        cf,
+	}
+	h.jobs[j.ID] = j
+	h.wg.Add(1)
+	go h.run(jCtx, j, hosts, ports)
+	return j.ID, nil
+}
+
+// Cancel stops a running job. Jobs that already finished are removed.
+func (h *Handler) Cancel(_ context.Context, id, owner string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	j, err := h.getJob(id, owner)
+	if err != nil {
+		return err
+	}
+	if j.Completed != nil {
+		delete(h.jobs, id)
+		return nil
+	}
+	if j.State == model.NetScanJobRunning {
+		j.State = model.NetScanJobCanceled
+		j.cf()
+	}
+	return nil
+}
+
+func (h *Handler) getJob(id, owner string) (*job, error) {
+	if owner == "" {
+		return nil, model.NewInvalidInputError(errors.New("missing owner"))
+	}
+	j, ok := h.jobs[id]
+	if !ok {
+		return nil, newNotFoundErr(id)
+	}
+	// jobs of other owners are reported as missing, so their existence and owners are not disclosed
+	if j.Owner != owner {
+		return nil, newNotFoundErr(id)
+	}
+	return j, nil
+}
+
+func (h *Handler) runningJobs() int {
+	var n int
+	for _, j := range h.jobs {
+		if j.Completed == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (h *Handler) run(ctx context.Context, j *job, hosts []netip.Addr, ports []int) {
+	defer h.wg.Done()
+	defer j.cf()
+	var wg sync.WaitGroup
+loop:
+	for _, host := range hosts {
+		for _, port := range ports {
+			select {
+			case <-ctx.Done():
+				break loop
+			case <-h.limiter.C:
+			}
+			select {
+			case <-ctx.Done():
+				break loop
+			case h.sem <- struct{}{}:
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				open := h.probe(ctx, netip.AddrPortFrom(host, uint16(port)))
+				<-h.sem
+				if ctx.Err() != nil {
+					return
+				}
+				h.setResult(j, host, port, open)
+			}()
+		}
+	}
+	wg.Wait()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	j.Completed = &timestamp
+	if j.State == model.NetScanJobRunning {
+		j.State = model.NetScanJobCompleted
+	}
+}
+
+func (h *Handler) probe(ctx context.Context, addrPort netip.AddrPort) bool {
+	conn, err := h.dialer.DialContext(ctx, "tcp4", addrPort.String())
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+func (h *Handler) setResult(j *job, host netip.Addr, port int, open bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	j.Finished++
+	if open {
+		j.openPorts[host] = append(j.openPorts[host], port)
+	}
+}
+
+func (h *Handler) checkNetwork(ctx context.Context, prefix netip.Prefix) error {
+	hostNet, err := h.netInfoHdl.GetNet(ctx)
+	if err != nil {
+		return err
+	}
+	for _, itf := range hostNet.Interfaces {
+		itfPrefix, err := netip.ParsePrefix(itf.IPv4Net)
+		if err != nil {
+			continue
+		}
+		if itfPrefix.Bits() <= prefix.Bits() && itfPrefix.Contains(prefix.Addr()) {
+			return nil
+		}
+	}
+	return model.NewInvalidInputError(fmt.Errorf("network '%s' does not belong to an available interface", prefix))
+}
+
+// getHosts returns the hosts of the network that are not in a blacklisted range.
+func (h *Handler) getHosts(ctx context.Context, prefix netip.Prefix) ([]netip.Addr, error) {
+	blacklistedNets, err := h.netInfoHdl.GetBlacklistedNets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []netip.Addr
+	for _, addr := range getHosts(prefix) {
+		if isBlacklisted(blacklistedNets, addr) {
+			continue
+		}
+		hosts = append(hosts, addr)
+	}
+	if len(hosts) == 0 {
+		return nil, model.NewInvalidInputError(fmt.Errorf("network '%s' only contains blacklisted hosts", prefix))
+	}
+	return hosts, nil
+}
+
+func (h *Handler) purge(ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timestamp := time.Now()
+	for id, j := range h.jobs {
+		if j.Completed != nil && j.Completed.Add(ttl).Before(timestamp) {
+			delete(h.jobs, id)
+		}
+	}
+}
+
+func (h *Handler) shutdown() {
+	h.mu.Lock()
+	h.stopped = true
+	for _, j := range h.jobs {
+		if j.State == model.NetScanJobRunning {
+			j.State = model.NetScanJobCanceled
+			j.cf()
+		}
+	}
+	h.mu.Unlock()
+	h.wg.Wait()
+	h.limiter.Stop()
+}
+
+// snapshot returns a copy of the job with hosts and ports in ascending order.
+func (j *job) snapshot() model.NetScanJob {
+	s := j.NetScanJob
+	s.Ports = append([]int(nil), j.Ports...)
+	if j.Completed != nil {
+		completed := *j.Completed
+		s.Completed = &completed
+	}
+	hosts := make([]model.NetScanHost, 0, len(j.openPorts))
+	for addr, ports := range j.openPorts {
+		openPorts := append([]int(nil), ports...)
+		sort.Ints(openPorts)
+		hosts = append(hosts, model.NetScanHost{
+			IPAddr:    addr.String(),
+			OpenPorts: openPorts,
+		})
+	}
+	sort.Slice(hosts, func(i, k int) bool {
+		return netip.MustParseAddr(hosts[i].IPAddr).Less(netip.MustParseAddr(hosts[k].IPAddr))
+	})
+	s.Hosts = hosts
+	return s
+}
+
+// getHosts returns all addresses of the network excluding the network and broadcast address if the network has more
+// than two addresses.
+func getHosts(prefix netip.Prefix) []netip.Addr {
+	var hosts []netip.Addr
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		hosts = append(hosts, addr)
+	}
+	if prefix.Bits() < 31 && len(hosts) > 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts
+}
+
+func isBlacklisted(blacklistedNets []*net.IPNet, addr netip.Addr) bool {
+	ip := net.IP(addr.AsSlice())
+	for _, ipNet := range blacklistedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func genPorts(values []int, maxPorts int) ([]int, error) {
+	if len(values) == 0 {
+		return nil, errors.New("missing ports")
+	}
+	set := make(map[int]struct{})
+	var ports []int
+	for _, port := range values {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %d", port)
+		}
+		if _, ok := set[port]; ok {
+			continue
+		}
+		set[port] = struct{}{}
+		ports = append(ports, port)
+	}
+	if len(ports) > maxPorts {
+		return nil, fmt.Errorf("number of ports exceeds maximum of %d", maxPorts)
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
+
+func newNotFoundErr(id string) error {
+	return model.NewNotFoundError(fmt.Errorf("scan job '%s' does not exist", id))
+}
@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scan_hdl
+
+import (
+	"context"
+	"errors"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testOwner = "test"
+
+type testNetInfoHdl struct {
+	interfaces      []lib_model.NetInterface
+	blacklistedNets []*net.IPNet
+}
+
+func (h *testNetInfoHdl) GetNet(_ context.Context) (lib_model.HostNet, error) {
+	return lib_model.HostNet{Interfaces: h.interfaces}, nil
+}
+
+func (h *testNetInfoHdl) GetBlacklistedNets(_ context.Context) ([]*net.IPNet, error) {
+	return h.blacklistedNets, nil
+}
+
+func newTestHandler(t *testing.T, rate int, blacklistedNets ...*net.IPNet) *Handler {
+	h, err := New(&testNetInfoHdl{interfaces: []lib_model.NetInterface{{Name: "lo", IPv4Addr: "127.0.0.1", IPv4Mask: "255.0.0.0", IPv4Net: "127.0.0.0/8"}}, blacklistedNets: blacklistedNets}, rate, 4, time.Second, 16, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cf := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Run(ctx, time.Hour, time.Hour)
+	}()
+	t.Cleanup(func() {
+		cf()
+		<-done
+	})
+	return h
+}
+
+func newTestListener(t *testing.T) int {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func getClosedPort(t *testing.T) int {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+	return port
+}
+
+func awaitJob(t *testing.T, h *Handler, id string) lib_model.NetScanJob {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		j, err := h.Get(context.Background(), id, testOwner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if j.Completed != nil {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("job not completed")
+	return lib_model.NetScanJob{}
+}
+
+func TestHandler_Add(t *testing.T) {
+	h := newTestHandler(t, 1000)
+	openPort := newTestListener(t)
+	closedPort := getClosedPort(t)
+	id, err := h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.1/32", Ports: []int{closedPort, openPort, openPort}, Owner: testOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := awaitJob(t, h, id)
+	if j.State != lib_model.NetScanJobCompleted {
+		t.Errorf("expected %s, got %s", lib_model.NetScanJobCompleted, j.State)
+	}
+	if j.Total != 2 || j.Finished != 2 {
+		t.Errorf("expected 2 of 2, got %d of %d", j.Finished, j.Total)
+	}
+	a := []lib_model.NetScanHost{{IPAddr: "127.0.0.1", OpenPorts: []int{openPort}}}
+	if !reflect.DeepEqual(a, j.Hosts) {
+		t.Errorf("expected %v, got %v", a, j.Hosts)
+	}
+	jobs, err := h.List(context.Background(), testOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != id || jobs[0].Owner != testOwner {
+		t.Errorf("expected job %s, got %v", id, jobs)
+	}
+	jobs, err = h.List(context.Background(), "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected no jobs, got %v", jobs)
+	}
+	var nfErr *lib_model.NotFoundError
+	if _, err = h.Get(context.Background(), id, "other"); !errors.As(err, &nfErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+	if err = h.Cancel(context.Background(), id, "other"); !errors.As(err, &nfErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+	t.Run("invalid requests", func(t *testing.T) {
+		requests := map[string]lib_model.NetScanRequest{
+			"invalid cidr":      {CIDR: "127.0.0.1", Ports: []int{80}, Owner: testOwner},
+			"ipv6":              {CIDR: "::1/128", Ports: []int{80}, Owner: testOwner},
+			"foreign network":   {CIDR: "10.0.0.0/30", Ports: []int{80}, Owner: testOwner},
+			"exceeds interface": {CIDR: "127.0.0.0/4", Ports: []int{80}, Owner: testOwner},
+			"too many hosts":    {CIDR: "127.0.0.0/24", Ports: []int{80}, Owner: testOwner},
+			"missing ports":     {CIDR: "127.0.0.1/32", Owner: testOwner},
+			"invalid port":      {CIDR: "127.0.0.1/32", Ports: []int{0}, Owner: testOwner},
+			"too many ports":    {CIDR: "127.0.0.1/32", Ports: []int{1, 2, 3, 4, 5}, Owner: testOwner},
+			"missing owner":     {CIDR: "127.0.0.1/32", Ports: []int{80}},
+		}
+		for name, req := range requests {
+			_, err := h.Add(context.Background(), req)
+			var iErr *lib_model.InvalidInputError
+			if !errors.As(err, &iErr) {
+				t.Errorf("%s: expected InvalidInputError, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestHandler_Cancel(t *testing.T) {
+	h := newTestHandler(t, 1)
+	id, err := h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.0/29", Ports: []int{getClosedPort(t)}, Owner: testOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Cancel(context.Background(), id, testOwner); err != nil {
+		t.Fatal(err)
+	}
+	j := awaitJob(t, h, id)
+	if j.State != lib_model.NetScanJobCanceled {
+		t.Errorf("expected %s, got %s", lib_model.NetScanJobCanceled, j.State)
+	}
+	if j.Finished >= j.Total {
+		t.Errorf("expected unfinished job, got %d of %d", j.Finished, j.Total)
+	}
+	if err = h.Cancel(context.Background(), id, testOwner); err != nil {
+		t.Fatal(err)
+	}
+	var nfErr *lib_model.NotFoundError
+	if _, err = h.Get(context.Background(), id, testOwner); !errors.As(err, &nfErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+	if err = h.Cancel(context.Background(), id, testOwner); !errors.As(err, &nfErr) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestHandler_MaxJobs(t *testing.T) {
+	h := newTestHandler(t, 1)
+	req := lib_model.NetScanRequest{CIDR: "127.0.0.0/29", Ports: []int{getClosedPort(t)}, Owner: testOwner}
+	var ids []string
+	for i := 0; i < 2; i++ {
+		id, err := h.Add(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	var cErr *lib_model.ConflictError
+	if _, err := h.Add(context.Background(), req); !errors.As(err, &cErr) {
+		t.Errorf("expected ConflictError, got %v", err)
+	}
+	if err := h.Cancel(context.Background(), ids[0], testOwner); err != nil {
+		t.Fatal(err)
+	}
+	awaitJob(t, h, ids[0])
+	id, err := h.Add(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = append(ids, id)
+	for _, id := range ids[1:] {
+		if err = h.Cancel(context.Background(), id, testOwner); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHandler_Blacklisted(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("127.0.0.0/30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newTestHandler(t, 1000, ipNet)
+	id, err := h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.0/29", Ports: []int{getClosedPort(t)}, Owner: testOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := awaitJob(t, h, id)
+	if j.Total != 3 || j.Finished != 3 {
+		t.Errorf("expected 3 of 3, got %d of %d", j.Finished, j.Total)
+	}
+	var iErr *lib_model.InvalidInputError
+	if _, err = h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.2/31", Ports: []int{80}, Owner: testOwner}); !errors.As(err, &iErr) {
+		t.Errorf("expected InvalidInputError, got %v", err)
+	}
+}
+
+func TestHandler_shutdown(t *testing.T) {
+	h, err := New(&testNetInfoHdl{interfaces: []lib_model.NetInterface{{Name: "lo", IPv4Addr: "127.0.0.1", IPv4Mask: "255.0.0.0", IPv4Net: "127.0.0.0/8"}}}, 1, 4, time.Second, 16, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.0/29", Ports: []int{getClosedPort(t)}, Owner: testOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.shutdown()
+	if j, err := h.Get(context.Background(), id, testOwner); err != nil || j.State != lib_model.NetScanJobCanceled {
+		t.Errorf("expected canceled job, got %+v, %v", j, err)
+	}
+	if _, err = h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.1/32", Ports: []int{80}, Owner: testOwner}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestHandler_purge(t *testing.T) {
+	h := newTestHandler(t, 1000)
+	id, err := h.Add(context.Background(), lib_model.NetScanRequest{CIDR: "127.0.0.1/32", Ports: []int{getClosedPort(t)}, Owner: testOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitJob(t, h, id)
+	h.purge(time.Hour)
+	if _, err = h.Get(context.Background(), id, testOwner); err != nil {
+		t.Error(err)
+	}
+	h.purge(0)
+	if _, err = h.Get(context.Background(), id, testOwner); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestGetHosts(t *testing.T) {
+	tests := map[string][]string{
+		"192.168.1.0/30":  {"192.168.1.1", "192.168.1.2"},
+		"192.168.1.0/31":  {"192.168.1.0", "192.168.1.1"},
+		"192.168.1.5/32":  {"192.168.1.5"},
+		"192.168.1.16/29": {"192.168.1.17", "192.168.1.18", "192.168.1.19", "192.168.1.20", "192.168.1.21", "192.168.1.22"},
+	}
+	for cidr, a := range tests {
+		var b []string
+		for _, addr := range getHosts(netip.MustParsePrefix(cidr)) {
+			b = append(b, addr.String())
+		}
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("%s: expected %v, got %v", cidr, a, b)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scan_hdl
+
+import (
+	"context"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+)
+
+type NetInfoHandler interface {
+	// GetNet returns the network interfaces not hidden by the interface and range blacklists.
+	GetNet(ctx context.Context) (lib_model.HostNet, error)
+	// GetBlacklistedNets returns the blacklisted network ranges.
+	GetBlacklistedNets(ctx context.Context) ([]*net.IPNet, error)
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ssdp_hdl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"golang.org/x/net/ipv4"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	multicastAddr      = "239.255.255.250:1900"
+	defaultTarget      = "ssdp:all"
+	descTimeout        = 2 * time.Second
+	maxDescriptionSize = 1 << 20
+	maxDescFetches     = 8
+	maxWindow          = 10 * time.Second
+)
+
+type Handler struct {
+	addr       string
+	httpClient *http.Client
+	netInfoHdl NetInfoHandler
+}
+
+func New() *Handler {
+	return NewWithAddr(multicastAddr)
+}
+
+// NewWithAddr creates a handler that sends search requests to addr instead of the SSDP multicast address.
+func NewWithAddr(addr string) *Handler {
+	return &Handler{
+		addr: addr,
+		httpClient: &http.Client{
+			Timeout: descTimeout,
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// SetNetInfoHandler limits search requests to the interfaces provided by the handler and omits responses from
+// blacklisted network ranges.
+func (h *Handler) SetNetInfoHandler(hdl NetInfoHandler) {
+	h.netInfoHdl = hdl
+}
+
+// Query sends a search request for the target and collects the responses received within the window. Responses are
+// de-duplicated by USN. If withDescription is true the device descriptions are fetched from the response locations.
+func (h *Handler) Query(ctx context.Context, target string, window time.Duration, withDescription bool) ([]model.SSDPDevice, error) {
+	if target == "" {
+		target = defaultTarget
+	}
+	if strings.IndexFunc(target, unicode.IsControl) >= 0 {
+		return nil, model.NewInvalidInputError(errors.New("invalid target"))
+	}
+	if window <= 0 {
+		return nil, model.NewInvalidInputError(errors.New("invalid time window"))
+	}
+	if window > maxWindow {
+		return nil, model.NewInvalidInputError(fmt.Errorf("time window exceeds maximum of %s", maxWindow))
+	}
+	rAddr, err := net.ResolveUDPAddr("udp4", h.addr)
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	col := collector{devices: make(map[string]model.SSDPDevice)}
+	if h.netInfoHdl == nil {
+		if err = search(ctx, nil, rAddr, target, window, &col); err != nil {
+			return nil, err
+		}
+	} else {
+		ifaces, err := h.getInterfaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if col.blacklist, err = h.netInfoHdl.GetBlacklistedNets(ctx); err != nil {
+			return nil, model.NewInternalError(err)
+		}
+		errs := make([]error, len(ifaces))
+		var wg sync.WaitGroup
+		for i := range ifaces {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = search(ctx, &ifaces[i], rAddr, target, window, &col)
+			}()
+		}
+		wg.Wait()
+		if err = errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, model.NewInternalError(ctx.Err())
+	}
+	deviceList := make([]model.SSDPDevice, 0, len(col.devices))
+	for _, device := range col.devices {
+		deviceList = append(deviceList, device)
+	}
+	sort.Slice(deviceList, func(i, j int) bool {
+		return deviceList[i].USN < deviceList[j].USN
+	})
+	if withDescription {
+		h.setDescriptions(ctx, deviceList)
+	}
+	return deviceList, nil
+}
+
+type searchInterface struct {
+	iface net.Interface
+	addr  net.IP
+}
+
+// getInterfaces returns the interfaces provided by the net info handler with their IPv4 addresses.
+func (h *Handler) getInterfaces(ctx context.Context) ([]searchInterface, error) {
+	hostNet, err := h.netInfoHdl.GetNet(ctx)
+	if err != nil {
+		return nil, model.NewInternalError(err)
+	}
+	var ifaces []searchInterface
+	for _, netItf := range hostNet.Interfaces {
+		ip := net.ParseIP(netItf.IPv4Addr).To4()
+		if ip == nil {
+			continue
+		}
+		iface, err := net.InterfaceByName(netItf.Name)
+		if err != nil {
+			continue
+		}
+		ifaces = append(ifaces, searchInterface{iface: *iface, addr: ip})
+	}
+	return ifaces, nil
+}
+
+// collector de-duplicates responses by USN and omits responses from blacklisted network ranges.
+type collector struct {
+	devices   map[string]model.SSDPDevice
+	blacklist []*net.IPNet
+	mu        sync.Mutex
+}
+
+func (c *collector) add(device model.SSDPDevice, ip net.IP) {
+	for _, ipNet := range c.blacklist {
+		if ipNet.Contains(ip) {
+			return
+		}
+	}
+	key := device.USN
+	if key == "" {
+		key = device.Location
+	}
+	c.mu.Lock()
+	c.devices[key] = device
+	c.mu.Unlock()
+}
+
+// search sends a search request via the interface or the default multicast interface if sItf is nil and adds the
+// responses received within the window to the collector.
+func search(ctx context.Context, sItf *searchInterface, rAddr *net.UDPAddr, target string, window time.Duration, col *collector) error {
+	var lAddr *net.UDPAddr
+	if sItf != nil {
+		lAddr = &net.UDPAddr{IP: sItf.addr}
+	}
+	conn, err := net.ListenUDP("udp4", lAddr)
+	if err != nil {
+		return model.NewInternalError(err)
+	}
+	defer conn.Close()
+	if sItf != nil {
+		if err = ipv4.NewPacketConn(conn).SetMulticastInterface(&sItf.iface); err != nil {
+			return model.NewInternalError(err)
+		}
+	}
+	if _, err = conn.WriteTo(newSearchRequest(target, window), rAddr); err != nil {
+		return model.NewInternalError(err)
+	}
+	if err = conn.SetReadDeadline(time.Now().Add(window)); err != nil {
+		return model.NewInternalError(err)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return nil
+			}
+			return model.NewInternalError(err)
+		}
+		device, ok := parseResponse(buf[:n])
+		if !ok {
+			continue
+		}
+		device.Addr = addr.IP.String()
+		col.add(device, addr.IP)
+	}
+}
+
+// setDescriptions fetches the descriptions of all devices. Each location is fetched once and at most maxDescFetches
+// fetches run concurrently. Devices without a retrievable description are left unchanged.
+func (h *Handler) setDescriptions(ctx context.Context, devices []model.SSDPDevice) {
+	type result struct {
+		desc *model.SSDPDeviceDescription
+		done chan struct{}
+	}
+	results := make(map[string]*result)
+	sem := make(chan struct{}, maxDescFetches)
+	for _, device := range devices {
+		if _, ok := results[device.Location]; ok {
+			continue
+		}
+		res := &result{done: make(chan struct{})}
+		results[device.Location] = res
+		go func() {
+			defer close(res.done)
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			desc, err := h.getDescription(ctx, device.Location, device.Addr)
+			if err == nil {
+				res.desc = &desc
+			}
+		}()
+	}
+	for i := range devices {
+		res := results[devices[i].Location]
+		<-res.done
+		if res.desc != nil {
+			desc := *res.desc
+			devices[i].Description = &desc
+		}
+	}
+}
+
+// getDescription fetches the device description from the location. Locations with a host other than the address of
+// the responding device are rejected.
+func (h *Handler) getDescription(ctx context.Context, location, addr string) (model.SSDPDeviceDescription, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return model.SSDPDeviceDescription{}, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return model.SSDPDeviceDescription{}, fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+	if host := net.ParseIP(u.Hostname()); host == nil || !host.Equal(net.ParseIP(addr)) {
+		return model.SSDPDeviceDescription{}, fmt.Errorf("location host '%s' does not match device address '%s'", u.Hostname(), addr)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return model.SSDPDeviceDescription{}, err
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return model.SSDPDeviceDescription{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return model.SSDPDeviceDescription{}, fmt.Errorf("unexpected status '%s'", resp.Status)
+	}
+	var root struct {
+		Device model.SSDPDeviceDescription `xml:"device"`
+	}
+	if err = xml.NewDecoder(io.LimitReader(resp.Body, maxDescriptionSize)).Decode(&root); err != nil {
+		return model.SSDPDeviceDescription{}, err
+	}
+	return root.Device, nil
+}
+
+func newSearchRequest(target string, window time.Duration) []byte {
+	// devices delay their response by a random time of up to MX seconds, valid values are 1 to 5
+	mx := int(window / time.Second)
+	if mx < 1 {
+		mx = 1
+	}
+	if mx > 5 {
+		mx = 5
+	}
+	return []byte(fmt.Sprintf("M-SEARCH * HTTP/1.1\r\nHOST: %s\r\nMAN: \"ssdp:discover\"\r\nMX: %d\r\nST: %s\r\n\r\n", multicastAddr, mx, target))
+}
+
+func parseResponse(b []byte) (model.SSDPDevice, bool) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), nil)
+	if err != nil {
+		return model.SSDPDevice{}, false
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return model.SSDPDevice{}, false
+	}
+	return model.SSDPDevice{
+		Location: resp.Header.Get("Location"),
+		Server:   resp.Header.Get("Server"),
+		USN:      resp.Header.Get("USN"),
+		ST:       resp.Header.Get("ST"),
+	}, true
+}
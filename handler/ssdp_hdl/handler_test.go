@@ -0,0 +1,310 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ssdp_hdl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:Basic:1</deviceType>
+    <friendlyName>Test Device</friendlyName>
+    <manufacturer>Test Inc.</manufacturer>
+    <modelName>T1</modelName>
+    <modelNumber>1.0</modelNumber>
+    <serialNumber>123</serialNumber>
+    <UDN>uuid:a</UDN>
+    <presentationURL>http://127.0.0.1/</presentationURL>
+  </device>
+</root>`
+
+type testResponder struct {
+	conn     *net.UDPConn
+	requests []string
+	mu       sync.Mutex
+}
+
+func newTestResponder(t *testing.T, responses []string) *testResponder {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &testResponder{conn: conn}
+	go func() {
+		buf := make([]byte, 2048)
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			r.mu.Lock()
+			r.requests = append(r.requests, string(buf[:n]))
+			r.mu.Unlock()
+			for _, resp := range responses {
+				_, _ = conn.WriteToUDP([]byte(resp), addr)
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return r
+}
+
+func newTestResponse(location, usn, st string) string {
+	return fmt.Sprintf("HTTP/1.1 200 OK\r\nCACHE-CONTROL: max-age=1800\r\nEXT:\r\nLOCATION: %s\r\nSERVER: Linux/5.0 UPnP/1.0 Test/1.0\r\nST: %s\r\nUSN: %s\r\n\r\n", location, st, usn)
+}
+
+func TestHandler_Query(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/desc.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testDescription))
+	}))
+	defer srv.Close()
+	responder := newTestResponder(t, []string{
+		newTestResponse(srv.URL+"/desc.xml", "uuid:a::upnp:rootdevice", "upnp:rootdevice"),
+		newTestResponse(srv.URL+"/desc.xml", "uuid:a::upnp:rootdevice", "upnp:rootdevice"),
+		newTestResponse(srv.URL+"/missing.xml", "uuid:b::upnp:rootdevice", "upnp:rootdevice"),
+		"invalid",
+	})
+	h := NewWithAddr(responder.conn.LocalAddr().String())
+	t.Run("without description", func(t *testing.T) {
+		devices, err := h.Query(context.Background(), "upnp:rootdevice", 200*time.Millisecond, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := []lib_model.SSDPDevice{
+			{
+				Location: srv.URL + "/desc.xml",
+				Server:   "Linux/5.0 UPnP/1.0 Test/1.0",
+				USN:      "uuid:a::upnp:rootdevice",
+				ST:       "upnp:rootdevice",
+				Addr:     "127.0.0.1",
+			},
+			{
+				Location: srv.URL + "/missing.xml",
+				Server:   "Linux/5.0 UPnP/1.0 Test/1.0",
+				USN:      "uuid:b::upnp:rootdevice",
+				ST:       "upnp:rootdevice",
+				Addr:     "127.0.0.1",
+			},
+		}
+		if !reflect.DeepEqual(a, devices) {
+			t.Errorf("expected %v, got %v", a, devices)
+		}
+		responder.mu.Lock()
+		defer responder.mu.Unlock()
+		if len(responder.requests) == 0 {
+			t.Fatal("no request received")
+		}
+		req := responder.requests[len(responder.requests)-1]
+		for _, s := range []string{"M-SEARCH * HTTP/1.1\r\n", "MAN: \"ssdp:discover\"\r\n", "MX: 1\r\n", "ST: upnp:rootdevice\r\n"} {
+			if !strings.Contains(req, s) {
+				t.Errorf("expected request to contain %q, got %q", s, req)
+			}
+		}
+	})
+	t.Run("with description", func(t *testing.T) {
+		devices, err := h.Query(context.Background(), "", 200*time.Millisecond, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(devices) != 2 {
+			t.Fatalf("expected 2 devices, got %d", len(devices))
+		}
+		a := &lib_model.SSDPDeviceDescription{
+			DeviceType:      "urn:schemas-upnp-org:device:Basic:1",
+			FriendlyName:    "Test Device",
+			Manufacturer:    "Test Inc.",
+			ModelName:       "T1",
+			ModelNumber:     "1.0",
+			SerialNumber:    "123",
+			UDN:             "uuid:a",
+			PresentationURL: "http://127.0.0.1/",
+		}
+		if !reflect.DeepEqual(a, devices[0].Description) {
+			t.Errorf("expected %v, got %v", a, devices[0].Description)
+		}
+		if devices[1].Description != nil {
+			t.Errorf("expected nil, got %v", devices[1].Description)
+		}
+		responder.mu.Lock()
+		defer responder.mu.Unlock()
+		if req := responder.requests[len(responder.requests)-1]; !strings.Contains(req, "ST: ssdp:all\r\n") {
+			t.Errorf("expected default target, got %q", req)
+		}
+	})
+	t.Run("invalid window", func(t *testing.T) {
+		if _, err := h.Query(context.Background(), "", 0, false); err == nil {
+			t.Error("expected error")
+		}
+		if _, err := h.Query(context.Background(), "", time.Minute, false); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("invalid target", func(t *testing.T) {
+		for _, target := range []string{"ssdp:all\r\nX-Test: 1", "ssdp:all\n", "ssdp:\x00all"} {
+			var iErr *lib_model.InvalidInputError
+			if _, err := h.Query(context.Background(), target, 200*time.Millisecond, false); !errors.As(err, &iErr) {
+				t.Errorf("%q: expected InvalidInputError, got %v", target, err)
+			}
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cf := context.WithCancel(context.Background())
+		cf()
+		if _, err := h.Query(ctx, "", time.Second, false); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestNewSearchRequest(t *testing.T) {
+	tests := map[time.Duration]string{
+		100 * time.Millisecond: "MX: 1\r\n",
+		3 * time.Second:        "MX: 3\r\n",
+		time.Minute:            "MX: 5\r\n",
+	}
+	for window, s := range tests {
+		if req := string(newSearchRequest("ssdp:all", window)); !strings.Contains(req, s) {
+			t.Errorf("expected %q in %q", s, req)
+		}
+	}
+}
+
+func TestHandler_QueryDescriptions(t *testing.T) {
+	var hits int
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/desc.xml":
+			mu.Lock()
+			hits++
+			mu.Unlock()
+			_, _ = w.Write([]byte(testDescription))
+		case "/redirect.xml":
+			http.Redirect(w, r, "/desc.xml", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	responder := newTestResponder(t, []string{
+		newTestResponse(srv.URL+"/desc.xml", "uuid:a::upnp:rootdevice", "upnp:rootdevice"),
+		newTestResponse(srv.URL+"/desc.xml", "uuid:a::urn:schemas-upnp-org:device:Basic:1", "urn:schemas-upnp-org:device:Basic:1"),
+		newTestResponse(srv.URL+"/redirect.xml", "uuid:b::upnp:rootdevice", "upnp:rootdevice"),
+		newTestResponse(strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/desc.xml", "uuid:c::upnp:rootdevice", "upnp:rootdevice"),
+	})
+	h := NewWithAddr(responder.conn.LocalAddr().String())
+	devices, err := h.Query(context.Background(), "", 200*time.Millisecond, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 4 {
+		t.Fatalf("expected 4 devices, got %d", len(devices))
+	}
+	for _, device := range devices {
+		withDesc := strings.HasPrefix(device.USN, "uuid:a::")
+		if (device.Description != nil) != withDesc {
+			t.Errorf("unexpected description for %s: %v", device.USN, device.Description)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 description request, got %d", hits)
+	}
+}
+
+type testNetInfoHdl struct {
+	hostNet   lib_model.HostNet
+	blacklist []*net.IPNet
+}
+
+func (h *testNetInfoHdl) GetNet(_ context.Context) (lib_model.HostNet, error) {
+	return h.hostNet, nil
+}
+
+func (h *testNetInfoHdl) GetBlacklistedNets(_ context.Context) ([]*net.IPNet, error) {
+	return h.blacklist, nil
+}
+
+func TestHandler_QueryNetInfo(t *testing.T) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loName string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			loName = iface.Name
+		}
+	}
+	if loName == "" {
+		t.Skip("no loopback interface")
+	}
+	responder := newTestResponder(t, []string{
+		newTestResponse("http://127.0.0.1/desc.xml", "uuid:a::upnp:rootdevice", "upnp:rootdevice"),
+	})
+	netInfoHdl := &testNetInfoHdl{}
+	h := NewWithAddr(responder.conn.LocalAddr().String())
+	h.SetNetInfoHandler(netInfoHdl)
+	t.Run("no interfaces", func(t *testing.T) {
+		devices, err := h.Query(context.Background(), "", 100*time.Millisecond, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(devices) != 0 {
+			t.Errorf("expected no devices, got %v", devices)
+		}
+	})
+	netInfoHdl.hostNet.Interfaces = []lib_model.NetInterface{{Name: loName, IPv4Addr: "127.0.0.1"}}
+	t.Run("interface", func(t *testing.T) {
+		devices, err := h.Query(context.Background(), "", 100*time.Millisecond, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(devices) != 1 {
+			t.Errorf("expected 1 device, got %v", devices)
+		}
+	})
+	_, ipNet, _ := net.ParseCIDR("127.0.0.0/8")
+	netInfoHdl.blacklist = []*net.IPNet{ipNet}
+	t.Run("blacklisted range", func(t *testing.T) {
+		devices, err := h.Query(context.Background(), "", 100*time.Millisecond, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(devices) != 0 {
+			t.Errorf("expected no devices, got %v", devices)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_hdl
+
+import (
+	"context"
+	"fmt"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"io/fs"
+	"net/url"
+	"path"
+	"time"
+)
+
+const connectTimeout = 500 * time.Millisecond
+
+type Handler struct {
+	fs  FileSystem
+	gid uint32
+}
+
+func New(gid int) *Handler {
+	return NewWithFS(osFS{}, gid)
+}
+
+func NewWithFS(fileSystem FileSystem, gid int) *Handler {
+	return &Handler{
+		fs:  fileSystem,
+		gid: uint32(gid),
+	}
+}
+
+// Get returns the status of a resource or false if the resource path is neither a filesystem path nor an
+// application endpoint. For application endpoints outside the filesystem only the listening state is set.
+func (h *Handler) Get(ctx context.Context, resource model.HostResource) (model.HostResourceStatus, bool) {
+	if resource.Type == model.Application {
+		return h.getEndpointStatus(ctx, resource.Path)
+	}
+	if !path.IsAbs(resource.Path) {
+		return model.HostResourceStatus{}, false
+	}
+	return h.getFileStatus(resource.Path), true
+}
+
+func (h *Handler) getEndpointStatus(ctx context.Context, uri string) (model.HostResourceStatus, bool) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return model.HostResourceStatus{}, false
+	}
+	var status model.HostResourceStatus
+	var network, address string
+	switch u.Scheme {
+	case model.HostAppEndpointUnix:
+		status = h.getFileStatus(u.Path)
+		if status.FileType != model.FileTypeSocket {
+			return status, true
+		}
+		network, address = "unix", u.Path
+	case model.HostAppEndpointUnixAbstract:
+		network, address = "unix", "@"+u.Opaque
+	case model.HostAppEndpointTCP:
+		network, address = "tcp", u.Host
+	default:
+		return model.HostResourceStatus{}, false
+	}
+	ctxWt, cf := context.WithTimeout(ctx, connectTimeout)
+	defer cf()
+	listening := h.fs.Connect(ctxWt, network, address) == nil
+	status.Listening = &listening
+	return status, true
+}
+
+func (h *Handler) getFileStatus(p string) model.HostResourceStatus {
+	var status model.HostResourceStatus
+	fi, err := h.fs.Stat(p)
+	if err != nil {
+		return status
+	}
+	status.Exists = true
+	status.FileType = getFileType(fi.Mode)
+	status.UID = fi.UID
+	status.GID = fi.GID
+	status.Mode = fmt.Sprintf("%04o", fi.Mode.Perm())
+	if h.traversable(path.Dir(p)) {
+		status.Readable = h.hasPerm(fi, 04)
+		status.Writable = h.hasPerm(fi, 02)
+	}
+	return status
+}
+
+// traversable checks if the GID has search permission on p and all parent directories.
+func (h *Handler) traversable(p string) bool {
+	for {
+		fi, err := h.fs.Stat(p)
+		if err != nil || !h.hasPerm(fi, 01) {
+			return false
+		}
+		if p == "/" {
+			return true
+		}
+		p = path.Dir(p)
+	}
+}
+
+// hasPerm checks the permission bits for the group if the GID owns the file, otherwise for others.
+func (h *Handler) hasPerm(fi FileInfo, perm fs.FileMode) bool {
+	if fi.GID == h.gid {
+		return fi.Mode.Perm()&(perm<<3) != 0
+	}
+	return fi.Mode.Perm()&perm != 0
+}
+
+func getFileType(mode fs.FileMode) string {
+	switch {
+	case mode.IsRegular():
+		return model.FileTypeRegular
+	case mode.IsDir():
+		return model.FileTypeDirectory
+	case mode&fs.ModeCharDevice != 0:
+		return model.FileTypeCharDevice
+	case mode&fs.ModeDevice != 0:
+		return model.FileTypeBlockDevice
+	case mode&fs.ModeSocket != 0:
+		return model.FileTypeSocket
+	case mode&fs.ModeNamedPipe != 0:
+		return model.FileTypeNamedPipe
+	default:
+		return model.FileTypeOther
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_hdl
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/mgw-host-manager/lib/model"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+type testFS struct {
+	files     map[string]FileInfo
+	listening map[string]bool
+}
+
+func (f testFS) Stat(p string) (FileInfo, error) {
+	fi, ok := f.files[p]
+	if !ok {
+		return FileInfo{}, os.ErrNotExist
+	}
+	return fi, nil
+}
+
+func (f testFS) Connect(_ context.Context, network, address string) error {
+	if !f.listening[network+":"+address] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestHandler_Get(t *testing.T) {
+	tfs := testFS{
+		files: map[string]FileInfo{
+			"/":             {Mode: fs.ModeDir | 0755},
+			"/dev":          {Mode: fs.ModeDir | 0755},
+			"/dev/ttyUSB0":  {Mode: fs.ModeDevice | fs.ModeCharDevice | 0660, GID: 20},
+			"/dev/ttyUSB1":  {Mode: fs.ModeDevice | fs.ModeCharDevice | 0600, GID: 20},
+			"/run":          {Mode: fs.ModeDir | 0750, GID: 30},
+			"/run/a.sock":   {Mode: fs.ModeSocket | 0666},
+			"/tmp":          {Mode: fs.ModeDir | 0777},
+			"/tmp/b.sock":   {Mode: fs.ModeSocket | 0666},
+			"/tmp/data.txt": {Mode: 0644, UID: 1000, GID: 1000},
+		},
+		listening: map[string]bool{"unix:/tmp/b.sock": true, "tcp:127.0.0.1:8080": true},
+	}
+	h := NewWithFS(tfs, 20)
+	t.Run("not a path", func(t *testing.T) {
+		if _, ok := h.Get(context.Background(), model.HostResource{Type: model.CANInterface, HostResourceBase: model.HostResourceBase{Path: "can0"}}); ok {
+			t.Error("expected no status")
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		status, ok := h.Get(context.Background(), model.HostResource{HostResourceBase: model.HostResourceBase{Path: "/dev/ttyACM0"}})
+		if !ok {
+			t.Fatal("expected status")
+		}
+		if status.Exists {
+			t.Error("expected missing resource")
+		}
+	})
+	t.Run("group access", func(t *testing.T) {
+		status, _ := h.Get(context.Background(), model.HostResource{HostResourceBase: model.HostResourceBase{Path: "/dev/ttyUSB0"}})
+		if !status.Exists || status.FileType != model.FileTypeCharDevice || status.Mode != "0660" || status.GID != 20 {
+			t.Errorf("unexpected status %+v", status)
+		}
+		if !status.Readable || !status.Writable {
+			t.Error("expected read and write access")
+		}
+		if status.Listening != nil {
+			t.Error("expected no listening state")
+		}
+	})
+	t.Run("no access", func(t *testing.T) {
+		status, _ := h.Get(context.Background(), model.HostResource{HostResourceBase: model.HostResourceBase{Path: "/dev/ttyUSB1"}})
+		if status.Readable || status.Writable {
+			t.Error("expected no access")
+		}
+	})
+	t.Run("other access", func(t *testing.T) {
+		status, _ := h.Get(context.Background(), model.HostResource{HostResourceBase: model.HostResourceBase{Path: "/tmp/data.txt"}})
+		if status.FileType != model.FileTypeRegular || !status.Readable || status.Writable {
+			t.Errorf("unexpected status %+v", status)
+		}
+	})
+	t.Run("parent not traversable", func(t *testing.T) {
+		status, _ := h.Get(context.Background(), model.HostResource{Type: model.Application, HostResourceBase: model.HostResourceBase{Path: "unix:///run/a.sock"}})
+		if !status.Exists || status.Readable || status.Writable {
+			t.Errorf("unexpected status %+v", status)
+		}
+		if status.Listening == nil || *status.Listening {
+			t.Error("expected socket not listening")
+		}
+	})
+	t.Run("listening", func(t *testing.T) {
+		status, _ := h.Get(context.Background(), model.HostResource{Type: model.Application, HostResourceBase: model.HostResourceBase{Path: "unix:///tmp/b.sock"}})
+		if status.FileType != model.FileTypeSocket || !status.Readable || !status.Writable {
+			t.Errorf("unexpected status %+v", status)
+		}
+		if status.Listening == nil || !*status.Listening {
+			t.Error("expected socket listening")
+		}
+	})
+	t.Run("network endpoints", func(t *testing.T) {
+		for uri, expected := range map[string]bool{"tcp://127.0.0.1:8080": true, "tcp://127.0.0.1:8081": false, "unix-abstract:test": false} {
+			status, ok := h.Get(context.Background(), model.HostResource{Type: model.Application, HostResourceBase: model.HostResourceBase{Path: uri}})
+			if !ok {
+				t.Fatal("expected status")
+			}
+			if status.Exists || status.Listening == nil || *status.Listening != expected {
+				t.Errorf("unexpected status for %s: %+v", uri, status)
+			}
+		}
+	})
+}
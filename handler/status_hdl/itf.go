@@ -0,0 +1,35 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_hdl
+
+import (
+	"context"
+	"io/fs"
+)
+
+type FileInfo struct {
+	Mode fs.FileMode
+	UID  uint32
+	GID  uint32
+}
+
+type FileSystem interface {
+	// Stat returns information about the file at p, following symbolic links.
+	Stat(p string) (FileInfo, error)
+	// Connect attempts to connect to the address on the named network.
+	Connect(ctx context.Context, network, address string) error
+}
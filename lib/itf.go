@@ -26,17 +26,45 @@ import (
 type Api interface {
 	GetHostInfo(ctx context.Context) (model.HostInfo, error)
 	GetHostNet(ctx context.Context) (model.HostNet, error)
-	ListHostResources(ctx context.Context, filter model.HostResourceFilter) ([]model.HostResource, error)
-	GetHostResource(ctx context.Context, rID string) (model.HostResource, error)
+	GetHostNetNeighbors(ctx context.Context, filter model.NetNeighborFilter) ([]model.NetNeighbor, error)
+	ListHostResources(ctx context.Context, filter model.HostResourceFilter, withStatus bool) (model.HostResourceList, error)
+	GetHostResource(ctx context.Context, rID string, withStatus bool) (model.HostResource, error)
+	ListHostResourceAliases(ctx context.Context) ([]model.ResourceAlias, error)
+	ReserveHostResource(ctx context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error)
+	RenewHostResourceReservation(ctx context.Context, rID, owner string, ttl time.Duration) (model.ResourceReservation, error)
+	ReleaseHostResourceReservation(ctx context.Context, rID, owner string) error
+	SetHostResourceAnnotation(ctx context.Context, rID string, annotation model.ResourceAnnotation) error
+	UpdateHostResourceAnnotation(ctx context.Context, rID string, patch model.ResourceAnnotationPatch) error
+	RemoveHostResourceAnnotation(ctx context.Context, rID string) error
+	GetStaticResourcesStatus(ctx context.Context) (model.StaticResourcesStatus, error)
 	ListHostApplications(ctx context.Context) ([]model.HostApplication, error)
 	AddHostApplication(ctx context.Context, appResBase model.HostApplicationBase) (string, error)
+	UpdateHostApplication(ctx context.Context, aID string, appResBase model.HostApplicationBase) error
+	PatchHostApplication(ctx context.Context, aID string, patch model.HostApplicationPatch) error
 	RemoveHostApplication(ctx context.Context, aID string) error
+	GetHostApplicationHealth(ctx context.Context, aID string) (model.HostAppHealth, error)
 	GetNetItfBlacklist(ctx context.Context) ([]string, error)
 	NetItfBlacklistAdd(ctx context.Context, v string) error
 	NetItfBlacklistRemove(ctx context.Context, v string) error
 	GetNetRngBlacklist(ctx context.Context) ([]string, error)
 	NetRngBlacklistAdd(ctx context.Context, v string) error
 	NetRngBlacklistRemove(ctx context.Context, v string) error
-	MDNSQueryService(ctx context.Context, service, domain string, window time.Duration) ([]model.MDNSEntry, error)
+	GetAppSocketBlacklist(ctx context.Context) ([]string, error)
+	AppSocketBlacklistAdd(ctx context.Context, v string) error
+	AppSocketBlacklistRemove(ctx context.Context, v string) error
+	MDNSQueryService(ctx context.Context, service, domain string, window time.Duration, options model.MDNSQueryOptions) ([]model.MDNSEntry, error)
+	MDNSQueryServiceStream(ctx context.Context, service, domain string, window time.Duration, options model.MDNSQueryOptions) (<-chan model.MDNSEntry, error)
+	MDNSQueryServiceTypes(ctx context.Context, domain string, window time.Duration, options model.MDNSQueryOptions) ([]model.MDNSServiceType, error)
+	MDNSLookupInstance(ctx context.Context, instance, service, domain string, window time.Duration, options model.MDNSQueryOptions) (model.MDNSEntry, error)
+	ListMDNSServices(ctx context.Context) ([]model.MDNSService, error)
+	GetMDNSService(ctx context.Context, id string) (model.MDNSService, error)
+	AddMDNSService(ctx context.Context, base model.MDNSServiceBase, owner string, ttl time.Duration) (model.MDNSService, error)
+	RenewMDNSService(ctx context.Context, id, owner string, ttl time.Duration) (model.MDNSService, error)
+	RemoveMDNSService(ctx context.Context, id, owner string) error
+	SSDPQuery(ctx context.Context, target string, window time.Duration, withDescription bool) ([]model.SSDPDevice, error)
+	ListNetScanJobs(ctx context.Context, owner string) ([]model.NetScanJob, error)
+	GetNetScanJob(ctx context.Context, id, owner string) (model.NetScanJob, error)
+	StartNetScan(ctx context.Context, req model.NetScanRequest) (string, error)
+	CancelNetScanJob(ctx context.Context, id, owner string) error
 	srv_info_lib.Api
 }
@@ -16,12 +16,59 @@
 
 package model
 
+import "time"
+
 type HostApplication struct {
-	ID string `json:"id"`
+	ID          string         `json:"id"`
+	Health      *HostAppHealth `json:"health"`
+	Source      HostAppSource  `json:"source"`
+	Blacklisted bool           `json:"blacklisted"` // endpoint matches a blacklist entry added after registration
 	HostApplicationBase
 }
 
 type HostApplicationBase struct {
-	Name   string `json:"name"`
-	Socket string `json:"socket"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Socket       string              `json:"socket"` // socket path, abstract socket name starting with '@' or TCP address depending on the endpoint type
+	EndpointType HostAppEndpointType `json:"endpoint_type"`
+	Protocol     HostAppProtocol     `json:"protocol"`
+	BasePath     string              `json:"base_path"` // only for http
+	Labels       map[string]string   `json:"labels"`
+	Owner        string              `json:"owner"`
+	HealthCheck  *HostAppHealthCheck `json:"health_check"`
+}
+
+type HostAppSource = string
+
+type HostAppProtocol = string
+
+type HostAppEndpointType = string
+
+// HostApplicationPatch contains the fields to change, nil fields are kept.
+type HostApplicationPatch struct {
+	Name         *string              `json:"name"`
+	Description  *string              `json:"description"`
+	Socket       *string              `json:"socket"`
+	EndpointType *HostAppEndpointType `json:"endpoint_type"`
+	Protocol     *HostAppProtocol     `json:"protocol"`
+	BasePath     *string              `json:"base_path"`
+	Labels       map[string]string    `json:"labels"`
+	Owner        *string              `json:"owner"`
+	HealthCheck  *HostAppHealthCheck  `json:"health_check"`
+}
+
+// HostAppHealthCheck connects to the application socket at the given interval. If a path is set, a HTTP GET request
+// is sent to the path over the socket and the application is considered healthy for status codes below 400.
+type HostAppHealthCheck struct {
+	Path     string `json:"path"`
+	Interval int    `json:"interval"` // seconds
+	Timeout  int    `json:"timeout"`  // seconds
+}
+
+type HostAppHealth struct {
+	Healthy  bool      `json:"healthy"`
+	Checked  time.Time `json:"checked"`
+	Latency  float64   `json:"latency"` // seconds
+	Failures int       `json:"failures"`
+	Error    string    `json:"error"`
 }
@@ -17,14 +17,18 @@
 package model
 
 const (
-	HeaderRequestID = "X-Request-ID"
-	HeaderApiVer    = "X-Api-Version"
-	HeaderSrvName   = "X-Service"
+	HeaderRequestID  = "X-Request-ID"
+	HeaderApiVer     = "X-Api-Version"
+	HeaderSrvName    = "X-Service"
+	HeaderTotalCount = "X-Total-Count"
 )
 
+const ContentTypeNDJSON = "application/x-ndjson"
+
 const (
 	HostInfoPath      = "host-info"
 	HostNetPath       = "network"
+	HostNeighborsPath = "neighbors"
 	HostOsPath        = "os"
 	HostHwPath        = "hardware"
 	HostResourcesPath = "host-resources"
@@ -34,10 +38,69 @@ const (
 	BlacklistsPath    = "blacklists"
 	NetInterfacesPath = "net-interfaces"
 	NetRangesPath     = "net-ranges"
+	AppSocketsPath    = "app-sockets"
 	MDNSDiscoveryPath = "mdns-discovery"
+	MDNSServicesPath  = "mdns-services"
+	SSDPDiscoveryPath = "ssdp-discovery"
+	NetScanJobsPath   = "net-scan-jobs"
+	LeasePath         = "lease"
+	StreamPath        = "stream"
+	TypesPath         = "types"
+	InstancePath      = "instance"
+	ReservationPath   = "reservation"
+	AnnotationsPath   = "annotations"
+	AliasesPath       = "aliases"
+	StaticResPath     = "static-resources"
+	HealthPath        = "health"
+)
+
+const (
+	HostAppEndpointUnix         HostAppEndpointType = "unix"
+	HostAppEndpointUnixAbstract HostAppEndpointType = "unix-abstract"
+	HostAppEndpointTCP          HostAppEndpointType = "tcp"
+)
+
+const (
+	MDNSIPv4     MDNSIPType = "ipv4"
+	MDNSIPv6     MDNSIPType = "ipv6"
+	MDNSIPv4And6 MDNSIPType = "both"
+)
+
+const (
+	NetScanJobRunning   NetScanJobState = "running"
+	NetScanJobCompleted NetScanJobState = "completed"
+	NetScanJobCanceled  NetScanJobState = "canceled"
+)
+
+const (
+	HostAppSourceManual     HostAppSource = "manual"
+	HostAppSourceDiscovered HostAppSource = "discovered"
+)
+
+const (
+	HostAppProtocolHTTP HostAppProtocol = "http"
+	HostAppProtocolGRPC HostAppProtocol = "grpc"
+	HostAppProtocolRaw  HostAppProtocol = "raw"
+)
+
+const (
+	FileTypeRegular     = "regular"
+	FileTypeDirectory   = "directory"
+	FileTypeCharDevice  = "char_device"
+	FileTypeBlockDevice = "block_device"
+	FileTypeSocket      = "socket"
+	FileTypeNamedPipe   = "named_pipe"
+	FileTypeOther       = "other"
 )
 
 const (
 	SerialDevice ResourceType = "serial"
 	Application  ResourceType = "app"
+	GPIOChip     ResourceType = "gpio"
+	I2CBus       ResourceType = "i2c"
+	SPIDevice    ResourceType = "spi"
+	VideoDevice  ResourceType = "video"
+	SoundCard    ResourceType = "audio"
+	CANInterface ResourceType = "can"
+	Static       ResourceType = "static"
 )
@@ -32,6 +32,10 @@ type InvalidInputError struct {
 	cError
 }
 
+type ConflictError struct {
+	cError
+}
+
 func (e *cError) Error() string {
 	return e.err.Error()
 }
@@ -51,3 +55,7 @@ func NewNotFoundError(err error) error {
 func NewInvalidInputError(err error) error {
 	return &InvalidInputError{cError{err: err}}
 }
+
+func NewConflictError(err error) error {
+	return &ConflictError{cError{err: err}}
+}
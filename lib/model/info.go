@@ -33,3 +33,15 @@ type NetInterface struct {
 	IPv4Mask string `json:"ipv4_mask"`
 	IPv4Net  string `json:"ipv4_net"`
 }
+
+type NetNeighbor struct {
+	Interface string `json:"interface"`
+	IPAddr    string `json:"ip_addr"`
+	MACAddr   string `json:"mac_addr"`
+	State     string `json:"state"`  // neighbor state (e.g.: reachable, stale, permanent), ARP entries only distinguish between complete and permanent
+	Vendor    string `json:"vendor"` // vendor of the MAC address, empty if the OUI is unknown or the address is locally administered
+}
+
+type NetNeighborFilter struct {
+	MACPrefixes []string // hex digits with optional separators (e.g.: 'b8:27:eb')
+}
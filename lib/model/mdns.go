@@ -19,13 +19,59 @@ package model
 import "time"
 
 type MDNSEntry struct {
-	Name       string    `json:"name"`
-	Type       string    `json:"type"`
-	Subtypes   []string  `json:"subtypes"`
-	Domain     string    `json:"domain"`
-	Hostname   string    `json:"hostname"`
-	Port       int       `json:"port"`
-	IPv4Addr   string    `json:"ipv4_addr"`
-	TxtRecords []string  `json:"txt_records"`
-	Expiry     time.Time `json:"expiry"`
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Subtypes   []string          `json:"subtypes"`
+	Domain     string            `json:"domain"`
+	Hostname   string            `json:"hostname"` // first label of the hostname
+	FQDN       string            `json:"fqdn"`
+	Port       int               `json:"port"`
+	IPv4Addr   string            `json:"ipv4_addr"` // first IPv4 address
+	IPv4Addrs  []string          `json:"ipv4_addrs"`
+	IPv6Addrs  []string          `json:"ipv6_addrs"`
+	TxtRecords []string          `json:"txt_records"`
+	Txt        map[string]string `json:"txt"` // parsed TXT records with lower case keys, keys without value map to an empty string
+	Expiry     time.Time         `json:"expiry"`
+}
+
+type MDNSServiceType struct {
+	Type   string `json:"type"`
+	Domain string `json:"domain"`
+}
+
+type MDNSQueryOptions struct {
+	Fresh      bool       // browse the network even if the service is cached
+	Interfaces []string   // limit the query to the given interfaces
+	IPType     MDNSIPType // defaults to IPv4
+	Txt        []string   // only include entries with TXT records matching all items, 'key' requires the key and 'key=value' the value
+}
+
+type MDNSIPType = string
+
+type MDNSServiceBase struct {
+	Name       string   `json:"name"`   // instance name
+	Type       string   `json:"type"`   // service type (e.g.: '_http._tcp')
+	Domain     string   `json:"domain"` // defaults to 'local.'
+	Port       int      `json:"port"`
+	TxtRecords []string `json:"txt_records"`
+	Interfaces []string `json:"interfaces"` // announce on all allowed interfaces if empty
+}
+
+type MDNSService struct {
+	ID      string    `json:"id"`
+	Owner   string    `json:"owner"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"`
+	MDNSServiceBase
+}
+
+type MDNSServiceRequest struct {
+	MDNSServiceBase
+	Owner string `json:"owner"`
+	TTL   int64  `json:"ttl"` // seconds
+}
+
+type MDNSLeaseRequest struct {
+	Owner string `json:"owner"`
+	TTL   int64  `json:"ttl"` // seconds
 }
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type NetScanJobState = string
+
+type NetScanRequest struct {
+	CIDR  string `json:"cidr"` // IPv4 network that must belong to a host interface (e.g.: '192.168.1.0/24')
+	Ports []int  `json:"ports"`
+	Owner string `json:"owner"`
+}
+
+type NetScanHost struct {
+	IPAddr    string `json:"ip_addr"`
+	OpenPorts []int  `json:"open_ports"`
+}
+
+type NetScanJob struct {
+	ID        string          `json:"id"`
+	State     NetScanJobState `json:"state"`
+	Created   time.Time       `json:"created"`
+	Completed *time.Time      `json:"completed"`
+	Total     int             `json:"total"`    // number of connection attempts
+	Finished  int             `json:"finished"` // number of finished connection attempts
+	Hosts     []NetScanHost   `json:"hosts"`    // hosts with at least one open port
+	NetScanRequest
+}
@@ -19,16 +19,47 @@ package model
 type ResourceType = string
 
 type HostResource struct {
-	ID   string       `json:"id"`
-	Type ResourceType `json:"type"`
+	ID          string               `json:"id"`
+	Type        ResourceType         `json:"type"`
+	Reservation *ResourceReservation `json:"reservation"`
+	Status      *HostResourceStatus  `json:"status"`
 	HostResourceBase
 }
 
 type HostResourceBase struct {
-	Name string   `json:"name"`
-	Tags []string `json:"tags"`
-	Path string   `json:"path"`
+	Name       string            `json:"name"`
+	Tags       []string          `json:"tags"`
+	Path       string            `json:"path"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// HostResourceList contains the resources of all handlers that succeeded and an error for each handler that failed.
+type HostResourceList struct {
+	Resources []HostResource        `json:"resources"`
+	Errors    []HostResourceListErr `json:"errors"`
+}
+
+type HostResourceListErr struct {
+	Type  ResourceType `json:"type"`
+	Error string       `json:"error"`
 }
 
 type HostResourceFilter struct {
+	Tags []string
+}
+
+type HostResourceListOptions struct {
+	Status bool // include availability and permission status
+	ListOptions
+}
+
+type HostResourceStatus struct {
+	Exists    bool   `json:"exists"`
+	FileType  string `json:"file_type"`
+	UID       uint32 `json:"uid"`
+	GID       uint32 `json:"gid"`
+	Mode      string `json:"mode"`
+	Readable  bool   `json:"readable"`
+	Writable  bool   `json:"writable"`
+	Listening *bool  `json:"listening"`
 }
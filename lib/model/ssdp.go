@@ -0,0 +1,37 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type SSDPDevice struct {
+	Location    string                 `json:"location"`
+	Server      string                 `json:"server"`
+	USN         string                 `json:"usn"`
+	ST          string                 `json:"st"`
+	Addr        string                 `json:"addr"` // address of the responding host
+	Description *SSDPDeviceDescription `json:"description"`
+}
+
+type SSDPDeviceDescription struct {
+	DeviceType      string `json:"device_type" xml:"deviceType"`
+	FriendlyName    string `json:"friendly_name" xml:"friendlyName"`
+	Manufacturer    string `json:"manufacturer" xml:"manufacturer"`
+	ModelName       string `json:"model_name" xml:"modelName"`
+	ModelNumber     string `json:"model_number" xml:"modelNumber"`
+	SerialNumber    string `json:"serial_number" xml:"serialNumber"`
+	UDN             string `json:"udn" xml:"UDN"`
+	PresentationURL string `json:"presentation_url" xml:"presentationURL"`
+}
@@ -24,18 +24,33 @@ import (
 	srv_info_hdl "github.com/SENERGY-Platform/mgw-go-service-base/srv-info-hdl"
 	sb_util "github.com/SENERGY-Platform/mgw-go-service-base/util"
 	"github.com/SENERGY-Platform/mgw-go-service-base/watchdog"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/annotation_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/blacklist_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/http_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/identity_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/info_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/mdns_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/mdns_reg_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/reservation_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/application_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/audio_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/can_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/gpio_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/i2c_hdl"
 	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/serial_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/spi_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/static_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/resource_hdl/video_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/scan_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/ssdp_hdl"
+	"github.com/SENERGY-Platform/mgw-host-manager/handler/status_hdl"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
 	"github.com/SENERGY-Platform/mgw-host-manager/manager"
 	"github.com/SENERGY-Platform/mgw-host-manager/util"
 	"net/http"
 	"os"
+	"path/filepath"
 	"syscall"
 	"time"
 )
@@ -79,6 +94,12 @@ func main() {
 	watchdog.Logger = util.Logger
 	wtchdg := watchdog.New(syscall.SIGINT, syscall.SIGTERM)
 
+	bgCtx, bgCF := context.WithCancel(context.Background())
+	wtchdg.RegisterStopFunc(func() error {
+		bgCF()
+		return nil
+	})
+
 	netInterfaceBlacklistHdl, err := blacklist_hdl.New(config.Blacklist.NetInterfaceListPath)
 	if err != nil {
 		util.Logger.Error(err)
@@ -105,32 +126,191 @@ func main() {
 		return
 	}
 
-	hostInfoHdl, err := info_hdl.New(config.Blacklist.NetInterfaceList, config.Blacklist.NetRangeList, netInterfaceBlacklistHdl, netRangeBlacklistHdl)
+	hostInfoHdl, err := info_hdl.New(config.Blacklist.NetInterfaceList, config.Blacklist.NetRangeList, netInterfaceBlacklistHdl, netRangeBlacklistHdl, config.ProcfsPath)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	if config.OUIPath != "" {
+		if err = hostInfoHdl.LoadOUIFile(config.OUIPath); err != nil {
+			util.Logger.Error(err)
+			ec = 1
+			return
+		}
+	}
+
+	appSocketBlacklistHdl, err := blacklist_hdl.New(config.Blacklist.AppSocketListPath)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	appSocketBlacklistHdl.SetValidationFunc(application_hdl.ValidateBlacklistEntry)
+	if err = appSocketBlacklistHdl.Init(); err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	socketPath, err := filepath.Abs(config.Socket.Path)
 	if err != nil {
 		util.Logger.Error(err)
 		ec = 1
 		return
 	}
 
-	hostAppHdl, err := application_hdl.New(config.ApplicationsPath, config.Blacklist.AppSocketList)
+	hostAppHdl, err := application_hdl.New(config.ApplicationsPath, append(config.Blacklist.AppSocketList, socketPath))
 	if err != nil {
 		util.Logger.Error(err)
 		ec = 1
 		return
 	}
+	hostAppHdl.SetBlacklistHandler(appSocketBlacklistHdl)
+	hostAppHdl.SetNetInfoHandler(hostInfoHdl)
 	if err = hostAppHdl.Init(); err != nil {
 		util.Logger.Error(err)
 		ec = 1
 		return
 	}
+	if len(config.AppDiscoveryPaths) > 0 {
+		hostAppHdl.SetDiscoveryPaths(config.AppDiscoveryPaths)
+		if err = hostAppHdl.Discover(bgCtx); err != nil {
+			util.Logger.Errorf("discovering applications failed: %s", err)
+		}
+	}
+
+	go hostAppHdl.RunHealthChecks(bgCtx, time.Second)
+
+	resAnnotationHdl, err := annotation_hdl.New(config.AnnotationsPath)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	if err = resAnnotationHdl.Init(); err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	resIdentityHdl, err := identity_hdl.New(config.IdentitiesPath, config.IdentityMaxAge)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	if err = resIdentityHdl.Init(); err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	videoHdl := video_hdl.New(config.DevicePath, config.SysfsPath)
+	videoHdl.SetQueryCaps(config.VideoQueryCaps)
 
-	hostResourceHdl := resource_hdl.New(map[lib_model.ResourceType]resource_hdl.ResHandler{
-		lib_model.SerialDevice: serial_hdl.New(config.SerialDevicePath),
+	resHandlers := map[lib_model.ResourceType]resource_hdl.ResHandler{
+		lib_model.SerialDevice: serial_hdl.New(config.SerialDevicePath, config.SysfsPath),
 		lib_model.Application:  hostAppHdl,
-	})
+		lib_model.GPIOChip:     gpio_hdl.New(config.DevicePath, config.SysfsPath),
+		lib_model.I2CBus:       i2c_hdl.New(config.DevicePath, config.SysfsPath),
+		lib_model.SPIDevice:    spi_hdl.New(config.DevicePath, config.SysfsPath),
+		lib_model.VideoDevice:  videoHdl,
+		lib_model.SoundCard:    audio_hdl.New(config.DevicePath, config.ProcfsPath, config.SysfsPath),
+		lib_model.CANInterface: can_hdl.New(config.SysfsPath),
+	}
+
+	var staticResHdl *static_hdl.Handler
+	var staticResStatusHdl manager.StaticResourceHandler
+	if config.StaticResPath != "" {
+		staticResHdl, err = static_hdl.New(config.StaticResPath)
+		if err != nil {
+			util.Logger.Error(err)
+			ec = 1
+			return
+		}
+		staticResHdl.Load()
+		resHandlers[lib_model.Static] = staticResHdl
+		staticResStatusHdl = staticResHdl
+	}
+
+	hostResourceHdl := resource_hdl.New(resHandlers, resAnnotationHdl, resIdentityHdl, config.ResourceTimeout, config.ResourceCacheTTL)
 	util.Logger.Debugf("resource handlers: %s", sb_util.ToJsonStr(hostResourceHdl.Handlers()))
 
-	hm := manager.New(hostInfoHdl, hostResourceHdl, hostAppHdl, netInterfaceBlacklistHdl, netRangeBlacklistHdl, mdns_hdl.New(), srvInfoHdl)
+	go hostResourceHdl.Watch(bgCtx)
+
+	if len(config.AppDiscoveryPaths) > 0 {
+		go func() {
+			if err := hostAppHdl.WatchDiscovery(bgCtx, func() {
+				hostResourceHdl.Invalidate(lib_model.Application)
+			}); err != nil {
+				util.Logger.Errorf("watching application discovery paths failed: %s", err)
+			}
+		}()
+	}
+
+	resReservationHdl, err := reservation_hdl.New(config.ReservationsPath, config.ReservationMaxTTL)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	if err = resReservationHdl.Init(); err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	mdnsServiceHdl, err := mdns_reg_hdl.New(config.MDNSServicesPath, config.MDNS.ServiceMaxTTL)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+	mdnsServiceHdl.SetNetInfoHandler(hostInfoHdl)
+	if err = mdnsServiceHdl.Init(); err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	mdnsServiceDone := make(chan struct{})
+	go func() {
+		defer close(mdnsServiceDone)
+		mdnsServiceHdl.Run(bgCtx, time.Second)
+	}()
+	wtchdg.RegisterStopFunc(func() error {
+		<-mdnsServiceDone
+		return nil
+	})
+
+	mdnsHdl := mdns_hdl.New(config.MDNS.CacheSize)
+	mdnsHdl.SetNetInfoHandler(hostInfoHdl)
+	if len(config.MDNS.BrowseServices) > 0 {
+		go mdnsHdl.RunBrowser(bgCtx, config.MDNS.BrowseServices, config.MDNS.BrowseInterval, config.MDNS.BrowseWindow)
+	}
+
+	ssdpHdl := ssdp_hdl.New()
+	ssdpHdl.SetNetInfoHandler(hostInfoHdl)
+
+	netScanHdl, err := scan_hdl.New(hostInfoHdl, config.NetScan.Rate, config.NetScan.Concurrency, config.NetScan.Timeout, config.NetScan.MaxHosts, config.NetScan.MaxPorts, config.NetScan.MaxJobs)
+	if err != nil {
+		util.Logger.Error(err)
+		ec = 1
+		return
+	}
+
+	netScanDone := make(chan struct{})
+	go func() {
+		defer close(netScanDone)
+		netScanHdl.Run(bgCtx, time.Minute, config.NetScan.JobTTL)
+	}()
+	wtchdg.RegisterStopFunc(func() error {
+		<-netScanDone
+		return nil
+	})
+
+	hm := manager.New(hostInfoHdl, hostResourceHdl, resReservationHdl, resAnnotationHdl, staticResStatusHdl, status_hdl.New(config.ModuleGroupID), hostAppHdl, netInterfaceBlacklistHdl, netRangeBlacklistHdl, appSocketBlacklistHdl, mdnsHdl, mdnsServiceHdl, ssdpHdl, netScanHdl, srvInfoHdl)
 
 	httpHandler, err := http_hdl.New(hm, map[string]string{
 		lib_model.HeaderApiVer:  srvInfoHdl.GetVersion(),
@@ -24,24 +24,76 @@ import (
 
 type HostInfoHandler interface {
 	GetNet(ctx context.Context) (lib_model.HostNet, error)
+	GetNeighbors(ctx context.Context, filter lib_model.NetNeighborFilter) ([]lib_model.NetNeighbor, error)
 	GetCPU(ctx context.Context) error
 	GetRAM(ctx context.Context) error
 	GetOS(ctx context.Context) error
 }
 
 type HostResourceHandler interface {
-	List(ctx context.Context, filter lib_model.HostResourceFilter) ([]lib_model.HostResource, error)
+	List(ctx context.Context, filter lib_model.HostResourceFilter) (lib_model.HostResourceList, error)
 	Get(ctx context.Context, rID string) (lib_model.HostResource, error)
+	ResolveID(ctx context.Context, rID string) (string, error)
+	ListAliases(ctx context.Context) ([]lib_model.ResourceAlias, error)
+	Invalidate(types ...lib_model.ResourceType)
+}
+
+type ResourceStatusHandler interface {
+	Get(ctx context.Context, resource lib_model.HostResource) (lib_model.HostResourceStatus, bool)
+}
+
+type StaticResourceHandler interface {
+	Status(ctx context.Context) (lib_model.StaticResourcesStatus, error)
+}
+
+type ResourceReservationHandler interface {
+	List(ctx context.Context) ([]lib_model.ResourceReservation, error)
+	Get(ctx context.Context, rID string) (lib_model.ResourceReservation, error)
+	Reserve(ctx context.Context, rID, owner string, ttl time.Duration) (lib_model.ResourceReservation, error)
+	Renew(ctx context.Context, rID, owner string, ttl time.Duration) (lib_model.ResourceReservation, error)
+	Release(ctx context.Context, rID, owner string) error
+}
+
+type ResourceAnnotationHandler interface {
+	Set(ctx context.Context, rID string, annotation lib_model.ResourceAnnotation) error
+	Update(ctx context.Context, rID string, patch lib_model.ResourceAnnotationPatch) error
+	Remove(ctx context.Context, rID string) error
 }
 
 type HostApplicationHandler interface {
 	List(ctx context.Context) ([]lib_model.HostApplication, error)
 	Add(ctx context.Context, appResBase lib_model.HostApplicationBase) (string, error)
+	Update(ctx context.Context, aID string, appResBase lib_model.HostApplicationBase) error
+	Patch(ctx context.Context, aID string, patch lib_model.HostApplicationPatch) error
 	Remove(ctx context.Context, aID string) error
+	Health(ctx context.Context, aID string) (lib_model.HostAppHealth, error)
+	Rediscover(ctx context.Context)
 }
 
 type MDNSDiscoveryHandler interface {
-	Query(ctx context.Context, service, domain string, window time.Duration) ([]lib_model.MDNSEntry, error)
+	Query(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSEntry, error)
+	Stream(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (<-chan lib_model.MDNSEntry, error)
+	Types(ctx context.Context, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSServiceType, error)
+	Lookup(ctx context.Context, instance, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (lib_model.MDNSEntry, error)
+}
+
+type MDNSServiceHandler interface {
+	List(ctx context.Context) ([]lib_model.MDNSService, error)
+	Get(ctx context.Context, id string) (lib_model.MDNSService, error)
+	Add(ctx context.Context, base lib_model.MDNSServiceBase, owner string, ttl time.Duration) (lib_model.MDNSService, error)
+	Renew(ctx context.Context, id, owner string, ttl time.Duration) (lib_model.MDNSService, error)
+	Remove(ctx context.Context, id, owner string) error
+}
+
+type SSDPDiscoveryHandler interface {
+	Query(ctx context.Context, target string, window time.Duration, withDescription bool) ([]lib_model.SSDPDevice, error)
+}
+
+type NetScanHandler interface {
+	List(ctx context.Context, owner string) ([]lib_model.NetScanJob, error)
+	Get(ctx context.Context, id, owner string) (lib_model.NetScanJob, error)
+	Add(ctx context.Context, req lib_model.NetScanRequest) (string, error)
+	Cancel(ctx context.Context, id, owner string) error
 }
 
 type BlacklistHandler interface {
@@ -18,6 +18,7 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"github.com/SENERGY-Platform/mgw-go-service-base/srv-info-hdl"
 	srv_info_lib "github.com/SENERGY-Platform/mgw-go-service-base/srv-info-hdl/lib"
 	lib_model "github.com/SENERGY-Platform/mgw-host-manager/lib/model"
@@ -25,24 +26,40 @@ import (
 )
 
 type Manager struct {
-	hostInfoHdl        HostInfoHandler
-	hostResourceHdl    HostResourceHandler
-	hostAppHdl         HostApplicationHandler
-	netItfBlacklistHdl BlacklistHandler
-	netRngBlacklistHdl BlacklistHandler
-	mdnsDiscoveryHdl   MDNSDiscoveryHandler
-	srvInfoHdl         srv_info_hdl.SrvInfoHandler
+	hostInfoHdl         HostInfoHandler
+	hostResourceHdl     HostResourceHandler
+	resReservationHdl   ResourceReservationHandler
+	resAnnotationHdl    ResourceAnnotationHandler
+	staticResHdl        StaticResourceHandler
+	resStatusHdl        ResourceStatusHandler
+	hostAppHdl          HostApplicationHandler
+	netItfBlacklistHdl  BlacklistHandler
+	netRngBlacklistHdl  BlacklistHandler
+	appSockBlacklistHdl BlacklistHandler
+	mdnsDiscoveryHdl    MDNSDiscoveryHandler
+	mdnsServiceHdl      MDNSServiceHandler
+	ssdpDiscoveryHdl    SSDPDiscoveryHandler
+	netScanHdl          NetScanHandler
+	srvInfoHdl          srv_info_hdl.SrvInfoHandler
 }
 
-func New(hostInfoHandler HostInfoHandler, hostResourceHandler HostResourceHandler, hostAppHdl HostApplicationHandler, netItfBlacklistHdl, netRngBlacklistHdl BlacklistHandler, mdnsDiscoveryHdl MDNSDiscoveryHandler, srvInfoHandler srv_info_hdl.SrvInfoHandler) *Manager {
+func New(hostInfoHandler HostInfoHandler, hostResourceHandler HostResourceHandler, resReservationHdl ResourceReservationHandler, resAnnotationHdl ResourceAnnotationHandler, staticResHdl StaticResourceHandler, resStatusHdl ResourceStatusHandler, hostAppHdl HostApplicationHandler, netItfBlacklistHdl, netRngBlacklistHdl, appSockBlacklistHdl BlacklistHandler, mdnsDiscoveryHdl MDNSDiscoveryHandler, mdnsServiceHdl MDNSServiceHandler, ssdpDiscoveryHdl SSDPDiscoveryHandler, netScanHdl NetScanHandler, srvInfoHandler srv_info_hdl.SrvInfoHandler) *Manager {
 	return &Manager{
-		hostInfoHdl:        hostInfoHandler,
-		hostResourceHdl:    hostResourceHandler,
-		hostAppHdl:         hostAppHdl,
-		netItfBlacklistHdl: netItfBlacklistHdl,
-		netRngBlacklistHdl: netRngBlacklistHdl,
-		mdnsDiscoveryHdl:   mdnsDiscoveryHdl,
-		srvInfoHdl:         srvInfoHandler,
+		hostInfoHdl:         hostInfoHandler,
+		hostResourceHdl:     hostResourceHandler,
+		resReservationHdl:   resReservationHdl,
+		resAnnotationHdl:    resAnnotationHdl,
+		staticResHdl:        staticResHdl,
+		resStatusHdl:        resStatusHdl,
+		hostAppHdl:          hostAppHdl,
+		netItfBlacklistHdl:  netItfBlacklistHdl,
+		netRngBlacklistHdl:  netRngBlacklistHdl,
+		appSockBlacklistHdl: appSockBlacklistHdl,
+		mdnsDiscoveryHdl:    mdnsDiscoveryHdl,
+		mdnsServiceHdl:      mdnsServiceHdl,
+		ssdpDiscoveryHdl:    ssdpDiscoveryHdl,
+		netScanHdl:          netScanHdl,
+		srvInfoHdl:          srvInfoHandler,
 	}
 }
 
@@ -64,12 +81,118 @@ func (m *Manager) GetHostNet(ctx context.Context) (lib_model.HostNet, error) {
 	return netInfo, nil
 }
 
-func (m *Manager) ListHostResources(ctx context.Context, filter lib_model.HostResourceFilter) ([]lib_model.HostResource, error) {
-	return m.hostResourceHdl.List(ctx, filter)
+func (m *Manager) GetHostNetNeighbors(ctx context.Context, filter lib_model.NetNeighborFilter) ([]lib_model.NetNeighbor, error) {
+	return m.hostInfoHdl.GetNeighbors(ctx, filter)
 }
 
-func (m *Manager) GetHostResource(ctx context.Context, rID string) (lib_model.HostResource, error) {
-	return m.hostResourceHdl.Get(ctx, rID)
+func (m *Manager) ListHostResources(ctx context.Context, filter lib_model.HostResourceFilter, withStatus bool) (lib_model.HostResourceList, error) {
+	resourceList, err := m.hostResourceHdl.List(ctx, filter)
+	if err != nil {
+		return lib_model.HostResourceList{}, err
+	}
+	reservations, err := m.resReservationHdl.List(ctx)
+	if err != nil {
+		return lib_model.HostResourceList{}, err
+	}
+	reservationMap := make(map[string]lib_model.ResourceReservation)
+	for _, reservation := range reservations {
+		reservationMap[reservation.ResourceID] = reservation
+	}
+	resources := resourceList.Resources
+	for i := range resources {
+		if reservation, ok := reservationMap[resources[i].ID]; ok {
+			resources[i].Reservation = &reservation
+		}
+		if withStatus {
+			m.setResourceStatus(ctx, &resources[i])
+		}
+	}
+	return resourceList, nil
+}
+
+func (m *Manager) GetHostResource(ctx context.Context, rID string, withStatus bool) (lib_model.HostResource, error) {
+	resource, err := m.hostResourceHdl.Get(ctx, rID)
+	if err != nil {
+		return lib_model.HostResource{}, err
+	}
+	reservation, err := m.resReservationHdl.Get(ctx, resource.ID)
+	if err != nil {
+		var nfe *lib_model.NotFoundError
+		if !errors.As(err, &nfe) {
+			return lib_model.HostResource{}, err
+		}
+	} else {
+		resource.Reservation = &reservation
+	}
+	if withStatus {
+		m.setResourceStatus(ctx, &resource)
+	}
+	return resource, nil
+}
+
+func (m *Manager) setResourceStatus(ctx context.Context, resource *lib_model.HostResource) {
+	if status, ok := m.resStatusHdl.Get(ctx, *resource); ok {
+		resource.Status = &status
+	}
+}
+
+func (m *Manager) ListHostResourceAliases(ctx context.Context) ([]lib_model.ResourceAlias, error) {
+	return m.hostResourceHdl.ListAliases(ctx)
+}
+
+func (m *Manager) ReserveHostResource(ctx context.Context, rID, owner string, ttl time.Duration) (lib_model.ResourceReservation, error) {
+	resource, err := m.hostResourceHdl.Get(ctx, rID)
+	if err != nil {
+		return lib_model.ResourceReservation{}, err
+	}
+	return m.resReservationHdl.Reserve(ctx, resource.ID, owner, ttl)
+}
+
+func (m *Manager) RenewHostResourceReservation(ctx context.Context, rID, owner string, ttl time.Duration) (lib_model.ResourceReservation, error) {
+	rID, err := m.hostResourceHdl.ResolveID(ctx, rID)
+	if err != nil {
+		return lib_model.ResourceReservation{}, err
+	}
+	return m.resReservationHdl.Renew(ctx, rID, owner, ttl)
+}
+
+func (m *Manager) ReleaseHostResourceReservation(ctx context.Context, rID, owner string) error {
+	rID, err := m.hostResourceHdl.ResolveID(ctx, rID)
+	if err != nil {
+		return err
+	}
+	return m.resReservationHdl.Release(ctx, rID, owner)
+}
+
+func (m *Manager) SetHostResourceAnnotation(ctx context.Context, rID string, annotation lib_model.ResourceAnnotation) error {
+	resource, err := m.hostResourceHdl.Get(ctx, rID)
+	if err != nil {
+		return err
+	}
+	return m.resAnnotationHdl.Set(ctx, resource.ID, annotation)
+}
+
+func (m *Manager) UpdateHostResourceAnnotation(ctx context.Context, rID string, patch lib_model.ResourceAnnotationPatch) error {
+	resource, err := m.hostResourceHdl.Get(ctx, rID)
+	if err != nil {
+		return err
+	}
+	return m.resAnnotationHdl.Update(ctx, resource.ID, patch)
+}
+
+func (m *Manager) RemoveHostResourceAnnotation(ctx context.Context, rID string) error {
+	rID, err := m.hostResourceHdl.ResolveID(ctx, rID)
+	if err != nil {
+		return err
+	}
+	return m.resAnnotationHdl.Remove(ctx, rID)
+}
+
+func (m *Manager) GetStaticResourcesStatus(ctx context.Context) (lib_model.StaticResourcesStatus, error) {
+	if m.staticResHdl == nil {
+		return lib_model.StaticResourcesStatus{}, lib_model.NewNotFoundError(errors.New("static resources not enabled"))
+	}
+	return m.staticResHdl.Status(ctx)
 }
 
 func (m *Manager) ListHostApplications(ctx context.Context) ([]lib_model.HostApplication, error) {
@@ -77,11 +200,40 @@ func (m *Manager) ListHostApplications(ctx context.Context) ([]lib_model.HostApp
 }
 
 func (m *Manager) AddHostApplication(ctx context.Context, appResBase lib_model.HostApplicationBase) (string, error) {
-	return m.hostAppHdl.Add(ctx, appResBase)
+	aID, err := m.hostAppHdl.Add(ctx, appResBase)
+	if err != nil {
+		return "", err
+	}
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return aID, nil
+}
+
+func (m *Manager) UpdateHostApplication(ctx context.Context, aID string, appResBase lib_model.HostApplicationBase) error {
+	if err := m.hostAppHdl.Update(ctx, aID, appResBase); err != nil {
+		return err
+	}
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return nil
+}
+
+func (m *Manager) PatchHostApplication(ctx context.Context, aID string, patch lib_model.HostApplicationPatch) error {
+	if err := m.hostAppHdl.Patch(ctx, aID, patch); err != nil {
+		return err
+	}
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return nil
 }
 
 func (m *Manager) RemoveHostApplication(ctx context.Context, aID string) error {
-	return m.hostAppHdl.Remove(ctx, aID)
+	if err := m.hostAppHdl.Remove(ctx, aID); err != nil {
+		return err
+	}
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return nil
+}
+
+func (m *Manager) GetHostApplicationHealth(ctx context.Context, aID string) (lib_model.HostAppHealth, error) {
+	return m.hostAppHdl.Health(ctx, aID)
 }
 
 func (m *Manager) GetNetItfBlacklist(ctx context.Context) ([]string, error) {
@@ -108,8 +260,82 @@ func (m *Manager) NetRngBlacklistRemove(ctx context.Context, v string) error {
 	return m.netRngBlacklistHdl.Remove(ctx, v)
 }
 
-func (m *Manager) MDNSQueryService(ctx context.Context, service, domain string, window time.Duration) ([]lib_model.MDNSEntry, error) {
-	return m.mdnsDiscoveryHdl.Query(ctx, service, domain, window)
+func (m *Manager) GetAppSocketBlacklist(ctx context.Context) ([]string, error) {
+	return m.appSockBlacklistHdl.List(ctx)
+}
+
+func (m *Manager) AppSocketBlacklistAdd(ctx context.Context, v string) error {
+	if err := m.appSockBlacklistHdl.Add(ctx, v); err != nil {
+		return err
+	}
+	m.hostAppHdl.Rediscover(ctx)
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return nil
+}
+
+func (m *Manager) AppSocketBlacklistRemove(ctx context.Context, v string) error {
+	if err := m.appSockBlacklistHdl.Remove(ctx, v); err != nil {
+		return err
+	}
+	m.hostAppHdl.Rediscover(ctx)
+	m.hostResourceHdl.Invalidate(lib_model.Application)
+	return nil
+}
+
+func (m *Manager) MDNSQueryService(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSEntry, error) {
+	return m.mdnsDiscoveryHdl.Query(ctx, service, domain, window, options)
+}
+
+func (m *Manager) MDNSQueryServiceStream(ctx context.Context, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (<-chan lib_model.MDNSEntry, error) {
+	return m.mdnsDiscoveryHdl.Stream(ctx, service, domain, window, options)
+}
+
+func (m *Manager) MDNSQueryServiceTypes(ctx context.Context, domain string, window time.Duration, options lib_model.MDNSQueryOptions) ([]lib_model.MDNSServiceType, error) {
+	return m.mdnsDiscoveryHdl.Types(ctx, domain, window, options)
+}
+
+func (m *Manager) MDNSLookupInstance(ctx context.Context, instance, service, domain string, window time.Duration, options lib_model.MDNSQueryOptions) (lib_model.MDNSEntry, error) {
+	return m.mdnsDiscoveryHdl.Lookup(ctx, instance, service, domain, window, options)
+}
+
+func (m *Manager) ListMDNSServices(ctx context.Context) ([]lib_model.MDNSService, error) {
+	return m.mdnsServiceHdl.List(ctx)
+}
+
+func (m *Manager) GetMDNSService(ctx context.Context, id string) (lib_model.MDNSService, error) {
+	return m.mdnsServiceHdl.Get(ctx, id)
+}
+
+func (m *Manager) AddMDNSService(ctx context.Context, base lib_model.MDNSServiceBase, owner string, ttl time.Duration) (lib_model.MDNSService, error) {
+	return m.mdnsServiceHdl.Add(ctx, base, owner, ttl)
+}
+
+func (m *Manager) RenewMDNSService(ctx context.Context, id, owner string, ttl time.Duration) (lib_model.MDNSService, error) {
+	return m.mdnsServiceHdl.Renew(ctx, id, owner, ttl)
+}
+
+func (m *Manager) RemoveMDNSService(ctx context.Context, id, owner string) error {
+	return m.mdnsServiceHdl.Remove(ctx, id, owner)
+}
+
+func (m *Manager) SSDPQuery(ctx context.Context, target string, window time.Duration, withDescription bool) ([]lib_model.SSDPDevice, error) {
+	return m.ssdpDiscoveryHdl.Query(ctx, target, window, withDescription)
+}
+
+func (m *Manager) ListNetScanJobs(ctx context.Context, owner string) ([]lib_model.NetScanJob, error) {
+	return m.netScanHdl.List(ctx, owner)
+}
+
+func (m *Manager) GetNetScanJob(ctx context.Context, id, owner string) (lib_model.NetScanJob, error) {
+	return m.netScanHdl.Get(ctx, id, owner)
+}
+
+func (m *Manager) StartNetScan(ctx context.Context, req lib_model.NetScanRequest) (string, error) {
+	return m.netScanHdl.Add(ctx, req)
+}
+
+func (m *Manager) CancelNetScanJob(ctx context.Context, id, owner string) error {
+	return m.netScanHdl.Cancel(ctx, id, owner)
 }
 
 func (m *Manager) GetSrvInfo(_ context.Context) srv_info_lib.SrvInfo {
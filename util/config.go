@@ -23,7 +23,9 @@ import (
 	"github.com/y-du/go-log-level/level"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"reflect"
+	"time"
 )
 
 type SocketConfig struct {
@@ -48,15 +50,52 @@ type BlacklistConfig struct {
 	AppSocketList        []string `json:"app_socket_list" env_var:"BLACKLIST_APP_SOCKET_LIST"`
 	NetInterfaceListPath string   `json:"net_interface_list_path" env_var:"BLACKLIST_NET_INTERFACE_LIST_PATH"`
 	NetRangeListPath     string   `json:"net_range_list_path" env_var:"BLACKLIST_NET_RANGE_LIST_PATH"`
+	AppSocketListPath    string   `json:"app_socket_list_path" env_var:"BLACKLIST_APP_SOCKET_LIST_PATH"`
+}
+
+type MDNSConfig struct {
+	BrowseServices []string      `json:"browse_services" env_var:"MDNS_BROWSE_SERVICES"`
+	BrowseInterval time.Duration `json:"browse_interval" env_var:"MDNS_BROWSE_INTERVAL"`
+	BrowseWindow   time.Duration `json:"browse_window" env_var:"MDNS_BROWSE_WINDOW"`
+	CacheSize      int           `json:"cache_size" env_var:"MDNS_CACHE_SIZE"`
+	ServiceMaxTTL  time.Duration `json:"service_max_ttl" env_var:"MDNS_SERVICE_MAX_TTL"`
+}
+
+type NetScanConfig struct {
+	Rate        int           `json:"rate" env_var:"NET_SCAN_RATE"` // connection attempts per second
+	Concurrency int           `json:"concurrency" env_var:"NET_SCAN_CONCURRENCY"`
+	Timeout     time.Duration `json:"timeout" env_var:"NET_SCAN_TIMEOUT"`
+	MaxHosts    int           `json:"max_hosts" env_var:"NET_SCAN_MAX_HOSTS"`
+	MaxPorts    int           `json:"max_ports" env_var:"NET_SCAN_MAX_PORTS"`
+	MaxJobs     int           `json:"max_jobs" env_var:"NET_SCAN_MAX_JOBS"` // number of jobs running at the same time
+	JobTTL      time.Duration `json:"job_ttl" env_var:"NET_SCAN_JOB_TTL"`   // time finished jobs are kept
 }
 
 type Config struct {
-	Logger           LoggerConfig    `json:"logger" env_var:"LOGGER_CONFIG"`
-	Socket           SocketConfig    `json:"socket" env_var:"SOCKET_CONFIG"`
-	Blacklist        BlacklistConfig `json:"blacklist" env_var:"BLACKLIST_CONFIG"`
-	SerialDevicePath string          `json:"serial_device_path" env_var:"SERIAL_DEVICE_PATH"`
-	ApplicationsPath string          `json:"applications_path" env_var:"APPLICATIONS_PATH"`
-	CoreID           string          `json:"core_id" env_var:"CORE_ID"`
+	Logger            LoggerConfig    `json:"logger" env_var:"LOGGER_CONFIG"`
+	Socket            SocketConfig    `json:"socket" env_var:"SOCKET_CONFIG"`
+	Blacklist         BlacklistConfig `json:"blacklist" env_var:"BLACKLIST_CONFIG"`
+	MDNS              MDNSConfig      `json:"mdns" env_var:"MDNS_CONFIG"`
+	NetScan           NetScanConfig   `json:"net_scan" env_var:"NET_SCAN_CONFIG"`
+	SerialDevicePath  string          `json:"serial_device_path" env_var:"SERIAL_DEVICE_PATH"`
+	DevicePath        string          `json:"device_path" env_var:"DEVICE_PATH"`
+	SysfsPath         string          `json:"sysfs_path" env_var:"SYSFS_PATH"`
+	VideoQueryCaps    bool            `json:"video_query_caps" env_var:"VIDEO_QUERY_CAPS"` // open video device nodes to read capture, output and metadata capabilities
+	ProcfsPath        string          `json:"procfs_path" env_var:"PROCFS_PATH"`
+	ApplicationsPath  string          `json:"applications_path" env_var:"APPLICATIONS_PATH"`
+	AppDiscoveryPaths []string        `json:"app_discovery_paths" env_var:"APP_DISCOVERY_PATHS"`
+	MDNSServicesPath  string          `json:"mdns_services_path" env_var:"MDNS_SERVICES_PATH"`
+	ReservationsPath  string          `json:"reservations_path" env_var:"RESERVATIONS_PATH"`
+	ReservationMaxTTL time.Duration   `json:"reservation_max_ttl" env_var:"RESERVATION_MAX_TTL"`
+	AnnotationsPath   string          `json:"annotations_path" env_var:"ANNOTATIONS_PATH"`
+	IdentitiesPath    string          `json:"identities_path" env_var:"IDENTITIES_PATH"`
+	IdentityMaxAge    time.Duration   `json:"identity_max_age" env_var:"IDENTITY_MAX_AGE"` // time identities of absent resources are kept
+	StaticResPath     string          `json:"static_resources_path" env_var:"STATIC_RESOURCES_PATH"`
+	OUIPath           string          `json:"oui_path" env_var:"OUI_PATH"` // IEEE MA-L registry in CSV format, extends the embedded vendor table
+	ModuleGroupID     int             `json:"module_group_id" env_var:"MODULE_GROUP_ID"`
+	ResourceTimeout   time.Duration   `json:"resource_timeout" env_var:"RESOURCE_TIMEOUT"`
+	ResourceCacheTTL  time.Duration   `json:"resource_cache_ttl" env_var:"RESOURCE_CACHE_TTL"`
+	CoreID            string          `json:"core_id" env_var:"CORE_ID"`
 }
 
 func NewConfig(path string) (*Config, error) {
@@ -73,8 +112,59 @@ func NewConfig(path string) (*Config, error) {
 			GroupID:  os.Getgid(),
 			FileMode: 0660,
 		},
-		SerialDevicePath: "/dev/serial/by-id",
+		MDNS: MDNSConfig{
+			BrowseInterval: 30 * time.Second,
+			BrowseWindow:   2 * time.Second,
+			CacheSize:      1000,
+			ServiceMaxTTL:  24 * time.Hour,
+		},
+		NetScan: NetScanConfig{
+			Rate:        100,
+			Concurrency: 32,
+			Timeout:     time.Second,
+			MaxHosts:    1024,
+			MaxPorts:    64,
+			MaxJobs:     4,
+			JobTTL:      time.Hour,
+		},
+		SerialDevicePath:  "/dev/serial/by-id",
+		DevicePath:        "/dev",
+		SysfsPath:         "/sys",
+		ProcfsPath:        "/proc",
+		ReservationMaxTTL: 24 * time.Hour,
+		IdentityMaxAge:    90 * 24 * time.Hour,
+		ModuleGroupID:     os.Getgid(),
+		ResourceTimeout:   5 * time.Second,
+		ResourceCacheTTL:  10 * time.Second,
 	}
-	err := config_hdl.Load(&cfg, nil, map[reflect.Type]envldr.Parser{reflect.TypeOf(level.Off): sb_logger.LevelParser}, nil, path)
-	return &cfg, err
+	err := config_hdl.Load(&cfg, nil, map[reflect.Type]envldr.Parser{reflect.TypeOf(level.Off): sb_logger.LevelParser, reflect.TypeOf(time.Duration(0)): durationParser}, nil, path)
+	if err != nil {
+		return &cfg, err
+	}
+	setDefaultStoragePaths(&cfg)
+	return &cfg, nil
+}
+
+// setDefaultStoragePaths places stores without a configured path next to the applications store, so existing
+// deployments keep working without additional configuration.
+func setDefaultStoragePaths(cfg *Config) {
+	if cfg.ApplicationsPath == "" {
+		return
+	}
+	dir := filepath.Dir(cfg.ApplicationsPath)
+	setDefaultPath(&cfg.ReservationsPath, dir, "reservations.json")
+	setDefaultPath(&cfg.AnnotationsPath, dir, "annotations.json")
+	setDefaultPath(&cfg.IdentitiesPath, dir, "identities.json")
+	setDefaultPath(&cfg.MDNSServicesPath, dir, "mdns_services.json")
+	setDefaultPath(&cfg.Blacklist.AppSocketListPath, dir, "app_socket_blacklist.json")
+}
+
+func setDefaultPath(p *string, dir, name string) {
+	if *p == "" {
+		*p = filepath.Join(dir, name)
+	}
+}
+
+var durationParser envldr.Parser = func(_ reflect.Type, val string, _ []string, _ map[string]string) (interface{}, error) {
+	return time.ParseDuration(val)
 }
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dir_watcher
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	mask          = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ATTRIB | syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+	removedMask   = syscall.IN_IGNORED | syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute
+)
+
+// ErrRemoved is returned by Watch if the watched directory was deleted or moved.
+var ErrRemoved = errors.New("watched directory removed")
+
+// Watch calls f after the content of directory p changed and blocks until ctx is done. Changes within the delay are
+// merged into a single call. If the directory is deleted or moved, f is called and ErrRemoved is returned, so the
+// caller can watch the directory again once it exists.
+func Watch(ctx context.Context, p string, delay time.Duration, f func()) error {
+	return WatchMatch(ctx, p, nil, delay, f)
+}
+
+// WatchMatch works like Watch but only changes of entries whose name is accepted by match cause a call of f. All
+// changes are considered if match is nil.
+func WatchMatch(ctx context.Context, p string, match func(name string) bool, delay time.Duration, f func()) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return os.NewSyscallError("inotify_init1", err)
+	}
+	if _, err = syscall.InotifyAddWatch(fd, p, mask); err != nil {
+		_ = syscall.Close(fd)
+		return &os.PathError{Op: "inotify_add_watch", Path: p, Err: err}
+	}
+	file := os.NewFile(uintptr(fd), "inotify")
+	defer file.Close()
+	events := make(chan error)
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := file.Read(buf)
+			if err == nil {
+				var ok bool
+				if ok, err = checkEvents(buf[:n], match); !ok && err == nil {
+					continue
+				}
+			}
+			select {
+			case events <- err:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-events:
+			if err != nil {
+				if errors.Is(err, ErrRemoved) {
+					f()
+				}
+				return err
+			}
+			timer.Reset(delay)
+		case <-timer.C:
+			f()
+		}
+	}
+}
+
+// WatchRetry works like WatchMatch but keeps watching if the directory does not exist or is removed. Watching is
+// retried with an increasing delay and f is called once the directory appears.
+func WatchRetry(ctx context.Context, p string, match func(name string) bool, delay time.Duration, f func()) error {
+	retryDelay := minRetryDelay
+	for {
+		err := WatchMatch(ctx, p, match, delay, f)
+		if errors.Is(err, ErrRemoved) {
+			retryDelay = minRetryDelay
+		} else if err == nil || !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		timer := time.NewTimer(retryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		retryDelay = min(retryDelay*2, maxRetryDelay)
+		if _, err = os.Stat(p); err == nil {
+			f()
+		}
+	}
+}
+
+// checkEvents reports whether one of the inotify events concerns an entry accepted by match and returns ErrRemoved if
+// the watch was removed.
+func checkEvents(b []byte, match func(name string) bool) (bool, error) {
+	var ok bool
+	for len(b) >= syscall.SizeofInotifyEvent {
+		// struct inotify_event: int32 wd, uint32 mask, uint32 cookie, uint32 len, followed by len bytes of name
+		if binary.NativeEndian.Uint32(b[4:8])&removedMask != 0 {
+			return false, ErrRemoved
+		}
+		n := syscall.SizeofInotifyEvent + int(binary.NativeEndian.Uint32(b[12:16]))
+		if n > len(b) {
+			break
+		}
+		if name := strings.TrimRight(string(b[syscall.SizeofInotifyEvent:n]), "\x00"); match == nil || (name != "" && match(name)) {
+			ok = true
+		}
+		b = b[n:]
+	}
+	return ok, nil
+}
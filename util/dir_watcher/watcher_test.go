@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dir_watcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Run("dir does not exist", func(t *testing.T) {
+		err := Watch(context.Background(), path.Join(tmpDir, "test"), time.Millisecond, func() {})
+		if err == nil {
+			t.Error("error should not be nil")
+		}
+	})
+	ctx, cf := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 10)
+	errs := make(chan error)
+	go func() {
+		errs <- Watch(ctx, tmpDir, 50*time.Millisecond, func() {
+			calls <- struct{}{}
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	for _, name := range []string{"a.json", "b.json"} {
+		if err := os.WriteFile(path.Join(tmpDir, name), []byte("{}"), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Error("expected call")
+	}
+	select {
+	case <-calls:
+		t.Error("expected merged call")
+	case <-time.After(200 * time.Millisecond):
+	}
+	cf()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("watch did not return")
+	}
+}
+
+func TestWatchRemoved(t *testing.T) {
+	for _, name := range []string{"delete", "move"} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			dirPath := path.Join(tmpDir, "test")
+			if err := os.Mkdir(dirPath, 0775); err != nil {
+				t.Fatal(err)
+			}
+			calls := make(chan struct{}, 10)
+			errs := make(chan error)
+			go func() {
+				errs <- Watch(context.Background(), dirPath, 50*time.Millisecond, func() {
+					calls <- struct{}{}
+				})
+			}()
+			time.Sleep(50 * time.Millisecond)
+			var err error
+			if name == "delete" {
+				err = os.Remove(dirPath)
+			} else {
+				err = os.Rename(dirPath, path.Join(tmpDir, "moved"))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case err = <-errs:
+				if !errors.Is(err, ErrRemoved) {
+					t.Errorf("expected ErrRemoved, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("watch did not return")
+			}
+			if len(calls) == 0 {
+				t.Error("expected call")
+			}
+		})
+	}
+}
+
+func TestWatchMatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	ctx, cf := context.WithCancel(context.Background())
+	defer cf()
+	calls := make(chan struct{}, 10)
+	go func() {
+		_ = WatchMatch(ctx, tmpDir, func(name string) bool {
+			return name == "b"
+		}, 50*time.Millisecond, func() {
+			calls <- struct{}{}
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path.Join(tmpDir, "a"), nil, 0664); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-calls:
+		t.Error("expected no call")
+	case <-time.After(200 * time.Millisecond):
+	}
+	if err := os.WriteFile(path.Join(tmpDir, "b"), nil, 0664); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Error("expected call")
+	}
+}
+
+func TestWatchRetry(t *testing.T) {
+	dirPath := path.Join(t.TempDir(), "test")
+	ctx, cf := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 10)
+	errs := make(chan error)
+	go func() {
+		errs <- WatchRetry(ctx, dirPath, nil, 50*time.Millisecond, func() {
+			calls <- struct{}{}
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := os.Mkdir(dirPath, 0775); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-calls:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected call after directory appeared")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path.Join(dirPath, "a"), nil, 0664); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Error("expected call")
+	}
+	cf()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("watch did not return")
+	}
+}
@@ -31,6 +31,10 @@ func GetStatusCode(err error) int {
 	if errors.As(err, &iie) {
 		return http.StatusBadRequest
 	}
+	var ce *model.ConflictError
+	if errors.As(err, &ce) {
+		return http.StatusConflict
+	}
 	var ie *model.InternalError
 	if errors.As(err, &ie) {
 		return http.StatusInternalServerError
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+func ReadSysfsAttr(p string) (string, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// GetUSBIdentity walks up the sysfs device tree starting at p and returns an identity composed of vendor ID, product ID
+// and serial number of the first USB device found. If the device has no serial number, the port path is used instead.
+func GetUSBIdentity(p string) (string, error) {
+	dir, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	for ; dir != path.Dir(dir); dir = path.Dir(dir) {
+		vendor, err := ReadSysfsAttr(path.Join(dir, "idVendor"))
+		if err != nil {
+			continue
+		}
+		product, err := ReadSysfsAttr(path.Join(dir, "idProduct"))
+		if err != nil {
+			return "", err
+		}
+		serial, err := ReadSysfsAttr(path.Join(dir, "serial"))
+		if err != nil || serial == "" {
+			serial = "port:" + path.Base(dir)
+		}
+		return fmt.Sprintf("usb:%s:%s:%s", vendor, product, serial), nil
+	}
+	return "", errors.New("no usb device")
+}
+
+// GetDeviceIdentity returns the USB identity of the device at sysfs path p if it is connected via USB. Otherwise, the
+// path of the device below the sysfs devices directory is used, which does not change if bus, card or interface numbers
+// are assigned in a different order.
+func GetDeviceIdentity(p string) (string, error) {
+	if identity, err := GetUSBIdentity(p); err == nil {
+		return identity, nil
+	}
+	dir, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	_, devPath, ok := strings.Cut(dir, "/devices/")
+	if !ok || devPath == "" {
+		return "", errors.New("no device")
+	}
+	return "dev:" + devPath, nil
+}